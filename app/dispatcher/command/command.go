@@ -0,0 +1,226 @@
+// +build !confonly
+
+package command
+
+//go:generate errorgen
+
+import (
+	"context"
+	"time"
+
+	grpc "google.golang.org/grpc"
+
+	"v2ray.com/core"
+	"v2ray.com/core/app/dispatcher"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/protocol"
+	"v2ray.com/core/features/routing"
+)
+
+// dispatcherServer is an implementation of DispatcherService.
+type dispatcherServer struct {
+	dispatcher routing.Dispatcher
+}
+
+func NewDispatcherServer(d routing.Dispatcher) DispatcherServiceServer {
+	return &dispatcherServer{
+		dispatcher: d,
+	}
+}
+
+func (s *dispatcherServer) getDispatcher() (*dispatcher.DefaultDispatcher, error) {
+	d, ok := s.dispatcher.(*dispatcher.DefaultDispatcher)
+	if !ok {
+		return nil, newError("DispatcherService only works with its own dispatcher.")
+	}
+	return d, nil
+}
+
+func matchAny(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		if f == value {
+			return true
+		}
+	}
+	return false
+}
+
+// match returns true if the session satisfies the filter. A nil filter matches all sessions.
+func (f *SessionFilter) match(info *dispatcher.SessionInfo) bool {
+	if f == nil {
+		return true
+	}
+	return matchAny(f.InboundTag, info.InboundTag) &&
+		matchAny(f.OutboundTag, info.OutboundTag) &&
+		matchAny(f.UserEmail, info.User)
+}
+
+func (f *SessionFilter) isEmpty() bool {
+	return f == nil || (len(f.InboundTag) == 0 && len(f.OutboundTag) == 0 && len(f.UserEmail) == 0)
+}
+
+func toSession(info *dispatcher.SessionInfo) *Session {
+	s := &Session{
+		SessionId:     uint32(info.ID),
+		InboundTag:    info.InboundTag,
+		UserEmail:     info.User,
+		SniffedDomain: info.SniffedDomain,
+		Protocol:      info.Protocol,
+		OutboundTag:   info.OutboundTag,
+		Uplink:        info.Uplink,
+		Downlink:      info.Downlink,
+	}
+	if info.Source.IsValid() {
+		s.Source = info.Source.String()
+	}
+	if info.Destination.IsValid() {
+		s.Destination = info.Destination.String()
+	}
+	if !info.Start.IsZero() {
+		s.StartTime = info.Start.Unix()
+	}
+	if !info.End.IsZero() {
+		s.EndTime = info.End.Unix()
+	}
+	return s
+}
+
+func (s *dispatcherServer) SubscribeSessions(request *SubscribeSessionsRequest, stream DispatcherService_SubscribeSessionsServer) error {
+	d, err := s.getDispatcher()
+	if err != nil {
+		return err
+	}
+
+	sub := d.SubscribeSessions()
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case msg := <-sub.Wait():
+			event, ok := msg.(*dispatcher.SessionEvent)
+			if !ok || !request.Filter.match(&event.SessionInfo) {
+				continue
+			}
+			e := &SessionEvent{
+				Session: toSession(&event.SessionInfo),
+			}
+			if event.Type == dispatcher.SessionEnded {
+				e.Type = SessionEvent_End
+			}
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *dispatcherServer) ListSessions(ctx context.Context, request *ListSessionsRequest) (*ListSessionsResponse, error) {
+	d, err := s.getDispatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListSessionsResponse{}
+	for _, info := range d.ListSessions() {
+		if request.Filter.match(&info) {
+			response.Session = append(response.Session, toSession(&info))
+		}
+	}
+	return response, nil
+}
+
+func (s *dispatcherServer) CloseSessions(ctx context.Context, request *CloseSessionsRequest) (*CloseSessionsResponse, error) {
+	d, err := s.getDispatcher()
+	if err != nil {
+		return nil, err
+	}
+	if request.SessionId == 0 && request.Filter.isEmpty() {
+		return nil, newError("neither session ID nor filter is specified")
+	}
+
+	closed := d.CloseSessions(func(info *dispatcher.SessionInfo) bool {
+		if request.SessionId != 0 && uint32(info.ID) != request.SessionId {
+			return false
+		}
+		return request.Filter.match(info)
+	})
+	return &CloseSessionsResponse{
+		Closed: uint32(closed),
+	}, nil
+}
+
+func (s *dispatcherServer) SetUserQuota(ctx context.Context, request *SetUserQuotaRequest) (*SetUserQuotaResponse, error) {
+	d, err := s.getDispatcher()
+	if err != nil {
+		return nil, err
+	}
+	if len(request.UserEmail) == 0 {
+		return nil, newError("user email is not specified")
+	}
+
+	d.SetUserQuota(request.UserEmail, request.Quota)
+	return &SetUserQuotaResponse{}, nil
+}
+
+func (s *dispatcherServer) ResetUserQuota(ctx context.Context, request *ResetUserQuotaRequest) (*ResetUserQuotaResponse, error) {
+	d, err := s.getDispatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if !d.ResetUserQuota(request.UserEmail) {
+		return nil, newError("user ", request.UserEmail, " has no quota")
+	}
+	return &ResetUserQuotaResponse{}, nil
+}
+
+func (s *dispatcherServer) GetUserQuota(ctx context.Context, request *GetUserQuotaRequest) (*GetUserQuotaResponse, error) {
+	d, err := s.getDispatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	status, found := d.GetUserQuota(request.UserEmail)
+	if !found {
+		return nil, newError("user ", request.UserEmail, " has no quota")
+	}
+	response := &GetUserQuotaResponse{
+		Quota: &protocol.Quota{
+			Total:    status.Total,
+			Periodic: status.Periodic,
+			Period:   uint32(status.Period / time.Second),
+		},
+		Used:       status.Used,
+		PeriodUsed: status.PeriodUsed,
+		Exceeded:   status.Exceeded,
+	}
+	if !status.PeriodStart.IsZero() {
+		response.PeriodStart = status.PeriodStart.Unix()
+	}
+	return response, nil
+}
+
+type service struct {
+	dispatcher routing.Dispatcher
+}
+
+func (s *service) Register(server *grpc.Server) {
+	RegisterDispatcherServiceServer(server, NewDispatcherServer(s.dispatcher))
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
+		s := new(service)
+
+		core.RequireFeatures(ctx, func(d routing.Dispatcher) {
+			s.dispatcher = d
+		})
+
+		return s, nil
+	}))
+}
@@ -17,9 +17,12 @@ import (
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/protocol"
 	"v2ray.com/core/common/session"
+	"v2ray.com/core/common/signal/pubsub"
+	"v2ray.com/core/features/dns"
 	"v2ray.com/core/features/outbound"
 	"v2ray.com/core/features/policy"
 	"v2ray.com/core/features/routing"
+	routing_session "v2ray.com/core/features/routing/session"
 	"v2ray.com/core/features/stats"
 	"v2ray.com/core/transport"
 	"v2ray.com/core/transport/pipe"
@@ -94,11 +97,20 @@ type DefaultDispatcher struct {
 	router routing.Router
 	policy policy.Manager
 	stats  stats.Manager
+
+	sessions *sessionManager
+	limiters limiterPool
+	quotas   *quotaManager
+
+	instance *core.Instance
+	fakeDNS  dns.FakeDNSEngine
 }
 
 func init() {
 	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
-		d := new(DefaultDispatcher)
+		d := &DefaultDispatcher{
+			instance: core.MustFromContext(ctx),
+		}
 		if err := core.RequireFeatures(ctx, func(om outbound.Manager, router routing.Router, pm policy.Manager, sm stats.Manager) error {
 			return d.Init(config.(*Config), om, router, pm, sm)
 		}); err != nil {
@@ -114,23 +126,80 @@ func (d *DefaultDispatcher) Init(config *Config, om outbound.Manager, router rou
 	d.router = router
 	d.policy = pm
 	d.stats = sm
+	d.sessions = newSessionManager(sm)
+	d.quotas = newQuotaManager(sm)
 	return nil
 }
 
+// SubscribeSessions returns a subscriber of SessionEvents of all connections routed by the dispatcher.
+// Events are dropped if the subscriber doesn't keep up.
+func (d *DefaultDispatcher) SubscribeSessions() *pubsub.Subscriber {
+	return d.sessions.pub.Subscribe(sessionEventTopic)
+}
+
+// ListSessions returns info of all active connections.
+func (d *DefaultDispatcher) ListSessions() []SessionInfo {
+	sessions := d.sessions.list()
+	infos := make([]SessionInfo, 0, len(sessions))
+	for _, t := range sessions {
+		infos = append(infos, t.getInfo())
+	}
+	return infos
+}
+
+// CloseSessions terminates all active connections that satisfy the given filter, and returns the number of them.
+func (d *DefaultDispatcher) CloseSessions(filter func(*SessionInfo) bool) int {
+	closed := 0
+	for _, t := range d.sessions.list() {
+		info := t.getInfo()
+		if filter(&info) {
+			t.interrupt()
+			closed++
+		}
+	}
+	return closed
+}
+
+// SetUserQuota sets the traffic quota of the user, overriding the one declared on the user.
+func (d *DefaultDispatcher) SetUserQuota(email string, quota *protocol.Quota) {
+	d.quotas.set(email, quota)
+}
+
+// ResetUserQuota clears the traffic usage of the user. It returns false if the user has no quota.
+func (d *DefaultDispatcher) ResetUserQuota(email string) bool {
+	return d.quotas.reset(email)
+}
+
+// GetUserQuota returns the traffic quota of the user and its usage.
+func (d *DefaultDispatcher) GetUserQuota(email string) (QuotaStatus, bool) {
+	return d.quotas.status(email)
+}
+
 // Type implements common.HasType.
 func (*DefaultDispatcher) Type() interface{} {
 	return routing.DispatcherType()
 }
 
+// SetFakeDNS sets the FakeDNS engine for restoring domains from fake IPs.
+func (d *DefaultDispatcher) SetFakeDNS(engine dns.FakeDNSEngine) {
+	d.fakeDNS = engine
+}
+
 // Start implements common.Runnable.
-func (*DefaultDispatcher) Start() error {
+func (d *DefaultDispatcher) Start() error {
+	// FakeDNS is optional, so it is looked up on start, when all features are registered.
+	if d.instance != nil && d.fakeDNS == nil {
+		if engine, ok := d.instance.GetFeature(dns.FakeDNSEngineType()).(dns.FakeDNSEngine); ok {
+			d.fakeDNS = engine
+		}
+	}
 	return nil
 }
 
 // Close implements common.Closable.
 func (*DefaultDispatcher) Close() error { return nil }
 
-func (d *DefaultDispatcher) getLink(ctx context.Context) (*transport.Link, *transport.Link) {
+func (d *DefaultDispatcher) getLink(ctx context.Context) (*transport.Link, *transport.Link, *sessionTracker, error) {
 	opt := pipe.OptionsFromContext(ctx)
 	uplinkReader, uplinkWriter := pipe.New(opt...)
 	downlinkReader, downlinkWriter := pipe.New(opt...)
@@ -152,6 +221,29 @@ func (d *DefaultDispatcher) getLink(ctx context.Context) (*transport.Link, *tran
 	}
 
 	if user != nil && len(user.Email) > 0 {
+		if q := d.quotas.get(user.Email, user.Quota); q != nil {
+			if q.isExceeded() {
+				return nil, nil, nil, newError("user ", user.Email, " has exceeded traffic quota")
+			}
+			email := user.Email
+			onCutoff := func() {
+				newError("user ", email, " has exceeded traffic quota, closing connections").AtInfo().WriteToLog()
+				d.CloseSessions(func(info *SessionInfo) bool {
+					return info.User == email
+				})
+			}
+			inboundLink.Writer = &quotaWriter{
+				quota:    q,
+				writer:   inboundLink.Writer,
+				onCutoff: onCutoff,
+			}
+			outboundLink.Writer = &quotaWriter{
+				quota:    q,
+				writer:   outboundLink.Writer,
+				onCutoff: onCutoff,
+			}
+		}
+
 		p := d.policy.ForLevel(user.Level)
 		if p.Stats.UserUplink {
 			name := "user>>>" + user.Email + ">>>traffic>>>uplink"
@@ -173,7 +265,73 @@ func (d *DefaultDispatcher) getLink(ctx context.Context) (*transport.Link, *tran
 		}
 	}
 
-	return inboundLink, outboundLink
+	var releaseLimiters []func()
+	if user != nil {
+		// Connections of the same user share the limit, while those of anonymous users are limited separately.
+		var name string
+		if len(user.Email) > 0 {
+			name = "user>>>" + user.Email
+		}
+		releaseLimiters = append(releaseLimiters, d.limiters.limitLinks(inboundLink, outboundLink, name, d.policy.ForLevel(user.Level).RateLimit)...)
+	}
+	if sessionInbound != nil && len(sessionInbound.Tag) > 0 {
+		if limit, found := d.policy.ForSystem().InboundRateLimit[sessionInbound.Tag]; found {
+			releaseLimiters = append(releaseLimiters, d.limiters.limitLinks(inboundLink, outboundLink, "inbound>>>"+sessionInbound.Tag, limit)...)
+		}
+	}
+
+	tracker := &sessionTracker{
+		info: SessionInfo{
+			ID: session.IDFromContext(ctx),
+		},
+		manager:         d.sessions,
+		uplinkPipe:      uplinkReader,
+		downlinkPipe:    downlinkReader,
+		releaseLimiters: releaseLimiters,
+	}
+	if sessionInbound != nil {
+		tracker.info.InboundTag = sessionInbound.Tag
+		tracker.info.Source = sessionInbound.Source
+		if user != nil {
+			tracker.info.User = user.Email
+		}
+	}
+	inboundLink.Writer = &sessionCountWriter{
+		counter: &tracker.uplink,
+		writer:  inboundLink.Writer,
+	}
+	outboundLink.Writer = &sessionCountWriter{
+		counter: &tracker.downlink,
+		writer:  outboundLink.Writer,
+		onClose: tracker.end,
+	}
+
+	var userLimit policy.UserLimit
+	if user != nil {
+		userLimit = d.policy.ForLevel(user.Level).UserLimit
+	}
+	if err := d.sessions.add(tracker, userLimit); err != nil {
+		for _, release := range releaseLimiters {
+			release()
+		}
+		return nil, nil, nil, err
+	}
+
+	if sessionInbound != nil && len(sessionInbound.Tag) > 0 {
+		name := "inbound>>>" + sessionInbound.Tag + ">>>connections"
+		if g, _ := stats.GetOrRegisterGauge(d.stats, name); g != nil {
+			g.Add(1)
+			tracker.inboundGauge = g
+		}
+	}
+	if user != nil && len(user.Email) > 0 && sessionInbound.Source.IsValid() && d.policy.ForLevel(user.Level).Stats.UserOnline {
+		name := "user>>>" + user.Email + ">>>online"
+		if om, _ := stats.GetOrRegisterOnlineMap(d.stats, name); om != nil {
+			tracker.setOnline(om, sessionInbound.Source.Address.String())
+		}
+	}
+
+	return inboundLink, outboundLink, tracker, nil
 }
 
 func shouldOverride(result SniffResult, domainOverride []string) bool {
@@ -195,37 +353,72 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 	}
 	ctx = session.ContextWithOutbound(ctx, ob)
 
-	inbound, outbound := d.getLink(ctx)
+	inbound, outbound, tracker, err := d.getLink(ctx)
+	if err != nil {
+		if accessMessage := log.AccessMessageFromContext(ctx); accessMessage != nil {
+			accessMessage.Status = log.AccessRejected
+			accessMessage.Reason = err
+			log.Record(accessMessage)
+		}
+		return nil, newError("connection rejected").Base(err)
+	}
 	content := session.ContentFromContext(ctx)
 	if content == nil {
 		content = new(session.Content)
 		ctx = session.ContextWithContent(ctx, content)
 	}
 	sniffingRequest := content.SniffingRequest
-	if destination.Network != net.Network_TCP || !sniffingRequest.Enabled {
-		go d.routedDispatch(ctx, outbound, destination)
-	} else {
+	switch {
+	case !sniffingRequest.Enabled:
+		go d.routedDispatch(ctx, outbound, destination, tracker)
+	case destination.Network != net.Network_TCP:
+		// Only metadata can be sniffed without reading the payload.
+		if result, err := d.sniffMetadata(destination); err == nil {
+			destination = d.applySniffResult(ctx, result, content, ob, tracker)
+		}
+		go d.routedDispatch(ctx, outbound, destination, tracker)
+	default:
 		go func() {
 			cReader := &cachedReader{
 				reader: outbound.Reader.(*pipe.Reader),
 			}
 			outbound.Reader = cReader
 			result, err := sniffer(ctx, cReader)
-			if err == nil {
-				content.Protocol = result.Protocol()
+			if err != nil || !shouldOverride(result, sniffingRequest.OverrideDestinationForProtocol) {
+				if metaResult, metaErr := d.sniffMetadata(destination); metaErr == nil {
+					result, err = metaResult, nil
+				}
 			}
-			if err == nil && shouldOverride(result, sniffingRequest.OverrideDestinationForProtocol) {
-				domain := result.Domain()
-				newError("sniffed domain: ", domain).WriteToLog(session.ExportIDToError(ctx))
-				destination.Address = net.ParseAddress(domain)
-				ob.Target = destination
+			if err == nil {
+				destination = d.applySniffResult(ctx, result, content, ob, tracker)
 			}
-			d.routedDispatch(ctx, outbound, destination)
+			d.routedDispatch(ctx, outbound, destination, tracker)
 		}()
 	}
 	return inbound, nil
 }
 
+// sniffMetadata sniffs the protocol from the destination of the connection, such as a fake IP from FakeDNS.
+func (d *DefaultDispatcher) sniffMetadata(destination net.Destination) (SniffResult, error) {
+	return sniffFakeDNS(d.fakeDNS, destination)
+}
+
+// applySniffResult records the sniffed result, and overrides the destination if requested. It returns the
+// destination to dispatch to.
+func (d *DefaultDispatcher) applySniffResult(ctx context.Context, result SniffResult, content *session.Content, ob *session.Outbound, tracker *sessionTracker) net.Destination {
+	content.Protocol = result.Protocol()
+	tracker.setSniffedDomain(result.Domain())
+
+	destination := ob.Target
+	if shouldOverride(result, content.SniffingRequest.OverrideDestinationForProtocol) {
+		domain := result.Domain()
+		newError("sniffed domain: ", domain).WriteToLog(session.ExportIDToError(ctx))
+		destination.Address = net.ParseAddress(domain)
+		ob.Target = destination
+	}
+	return destination
+}
+
 func sniffer(ctx context.Context, cReader *cachedReader) (SniffResult, error) {
 	payload := buf.New()
 	defer payload.Release()
@@ -256,7 +449,7 @@ func sniffer(ctx context.Context, cReader *cachedReader) (SniffResult, error) {
 	}
 }
 
-func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.Link, destination net.Destination) {
+func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.Link, destination net.Destination, tracker *sessionTracker) {
 	var handler outbound.Handler
 
 	skipRoutePick := false
@@ -265,7 +458,8 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 	}
 
 	if d.router != nil && !skipRoutePick {
-		if tag, err := d.router.PickRoute(ctx); err == nil {
+		if route, err := d.router.PickRoute(routing_session.AsRoutingContext(ctx)); err == nil {
+			tag := route.GetOutboundTag()
 			if h := d.ohm.GetHandler(tag); h != nil {
 				newError("taking detour [", tag, "] for [", destination, "]").WriteToLog(session.ExportIDToError(ctx))
 				handler = h
@@ -295,5 +489,11 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 		log.Record(accessMessage)
 	}
 
+	var protocol string
+	if content := session.ContentFromContext(ctx); content != nil {
+		protocol = content.Protocol
+	}
+	tracker.start(handler.Tag(), destination, protocol)
+
 	handler.Dispatch(ctx, link)
 }
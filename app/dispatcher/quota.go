@@ -0,0 +1,286 @@
+// +build !confonly
+
+package dispatcher
+
+import (
+	"sync"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/protocol"
+	"v2ray.com/core/features/stats"
+)
+
+// QuotaStatus describes the traffic quota of a user and its usage.
+type QuotaStatus struct {
+	// Total and Periodic are the limits in bytes. 0 for unlimited.
+	Total    uint64
+	Periodic uint64
+	Period   time.Duration
+	// Used is the number of bytes transferred in total, and PeriodUsed in the current period.
+	Used        uint64
+	PeriodUsed  uint64
+	PeriodStart time.Time
+	Exceeded    bool
+}
+
+// userQuota tracks the traffic of a user against its quota. The usage is kept in the state store of the stats
+// manager, if any, so that it is persisted along with other stats, but not reset by stats queries.
+type userQuota struct {
+	sync.Mutex
+	total    uint64
+	periodic uint64
+	period   time.Duration
+	// fromAPI is true if the quota is set by API. It then overrides the one declared on the user.
+	fromAPI bool
+	// applied is true once a quota has been set.
+	applied bool
+
+	used        uint64
+	periodUsed  uint64
+	periodStart time.Time
+	// cutoff is true once connections of the user have been closed for exceeding the quota.
+	cutoff bool
+
+	store stats.StateManager
+	name  string
+}
+
+func newUserQuota(sm stats.Manager, email string) *userQuota {
+	q := &userQuota{
+		name: "user>>>" + email + ">>>quota>>>",
+	}
+	if store, ok := sm.(stats.StateManager); ok {
+		q.store = store
+		q.load()
+	}
+	return q
+}
+
+// load restores the usage from the state store.
+func (q *userQuota) load() {
+	if v, found := q.store.GetState(q.name + "used"); found && v > 0 {
+		q.used = uint64(v)
+	}
+	if v, found := q.store.GetState(q.name + "period_used"); found && v > 0 {
+		q.periodUsed = uint64(v)
+	}
+	if v, found := q.store.GetState(q.name + "period_start"); found && v > 0 {
+		q.periodStart = time.Unix(v, 0)
+	}
+}
+
+// save writes the usage into the state store. It must be called with q locked.
+func (q *userQuota) save() {
+	if q.store == nil {
+		return
+	}
+	q.store.SetState(q.name+"used", int64(q.used))
+	q.store.SetState(q.name+"period_used", int64(q.periodUsed))
+	var start int64
+	if !q.periodStart.IsZero() {
+		start = q.periodStart.Unix()
+	}
+	q.store.SetState(q.name+"period_start", start)
+}
+
+// rollPeriod starts a new period if the current one is over. It must be called with q locked.
+func (q *userQuota) rollPeriod(now time.Time) {
+	if q.period <= 0 {
+		return
+	}
+	if q.periodStart.IsZero() {
+		// The start is kept in seconds, as it is in the state store.
+		q.periodStart = now.Truncate(time.Second)
+		q.save()
+		return
+	}
+	if elapsed := now.Sub(q.periodStart); elapsed >= q.period {
+		q.periodStart = q.periodStart.Add(elapsed - elapsed%q.period)
+		q.periodUsed = 0
+		q.cutoff = false
+		q.save()
+	}
+}
+
+// exceeded returns true if the user has used up its quota. It must be called with q locked.
+func (q *userQuota) exceeded() bool {
+	q.rollPeriod(time.Now())
+	return (q.total > 0 && q.used >= q.total) || (q.periodic > 0 && q.period > 0 && q.periodUsed >= q.periodic)
+}
+
+func (q *userQuota) isExceeded() bool {
+	q.Lock()
+	defer q.Unlock()
+	return q.exceeded()
+}
+
+// consume records n bytes of traffic. It returns false if the quota had been used up before, and
+// whether the traffic makes the quota used up for the first time.
+func (q *userQuota) consume(n uint64) (ok bool, cutoff bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.exceeded() {
+		return false, false
+	}
+	q.used += n
+	q.periodUsed += n
+	q.save()
+	if q.exceeded() && !q.cutoff {
+		q.cutoff = true
+		return true, true
+	}
+	return true, false
+}
+
+func (q *userQuota) setLimit(quota *protocol.Quota) {
+	q.total = quota.GetTotal()
+	q.periodic = quota.GetPeriodic()
+	period := time.Duration(quota.GetPeriod()) * time.Second
+	if period != q.period {
+		q.period = period
+		// The period restored from the state store is kept when the quota is applied for the first time.
+		if q.applied {
+			q.periodStart = time.Time{}
+			q.periodUsed = 0
+			q.save()
+		}
+	}
+	q.applied = true
+	q.cutoff = false
+}
+
+func (q *userQuota) reset() {
+	q.Lock()
+	defer q.Unlock()
+
+	q.used = 0
+	q.periodUsed = 0
+	q.periodStart = time.Time{}
+	q.cutoff = false
+	q.save()
+}
+
+func (q *userQuota) status() QuotaStatus {
+	q.Lock()
+	defer q.Unlock()
+
+	exceeded := q.exceeded()
+	return QuotaStatus{
+		Total:       q.total,
+		Periodic:    q.periodic,
+		Period:      q.period,
+		Used:        q.used,
+		PeriodUsed:  q.periodUsed,
+		PeriodStart: q.periodStart,
+		Exceeded:    exceeded,
+	}
+}
+
+// quotaManager keeps the quotas of users by their emails.
+type quotaManager struct {
+	access sync.Mutex
+	stats  stats.Manager
+	users  map[string]*userQuota
+}
+
+func newQuotaManager(sm stats.Manager) *quotaManager {
+	return &quotaManager{
+		stats: sm,
+		users: make(map[string]*userQuota),
+	}
+}
+
+// get returns the quota of the user, applying the declared quota unless it is overridden by API.
+// It returns nil if the user has no quota.
+func (m *quotaManager) get(email string, declared *protocol.Quota) *userQuota {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	q, found := m.users[email]
+	if !found {
+		if declared == nil {
+			return nil
+		}
+		q = newUserQuota(m.stats, email)
+		m.users[email] = q
+	}
+
+	q.Lock()
+	defer q.Unlock()
+	if !q.fromAPI && declared != nil && (declared.Total != q.total || declared.Periodic != q.periodic || time.Duration(declared.Period)*time.Second != q.period) {
+		q.setLimit(declared)
+	}
+	return q
+}
+
+func (m *quotaManager) set(email string, quota *protocol.Quota) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	q, found := m.users[email]
+	if !found {
+		q = newUserQuota(m.stats, email)
+		m.users[email] = q
+	}
+
+	q.Lock()
+	defer q.Unlock()
+	q.fromAPI = true
+	q.setLimit(quota)
+}
+
+// reset clears the usage of the user. It returns false if the user has no quota.
+func (m *quotaManager) reset(email string) bool {
+	m.access.Lock()
+	q, found := m.users[email]
+	m.access.Unlock()
+	if !found {
+		return false
+	}
+	q.reset()
+	return true
+}
+
+func (m *quotaManager) status(email string) (QuotaStatus, bool) {
+	m.access.Lock()
+	q, found := m.users[email]
+	m.access.Unlock()
+	if !found {
+		return QuotaStatus{}, false
+	}
+	return q.status(), true
+}
+
+// quotaWriter is a buf.Writer that counts traffic into a user's quota, and fails once the quota is used up.
+type quotaWriter struct {
+	quota  *userQuota
+	writer buf.Writer
+	// onCutoff is called when the quota is used up for the first time.
+	onCutoff func()
+}
+
+// WriteMultiBuffer implements buf.Writer.
+func (w *quotaWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	ok, cutoff := w.quota.consume(uint64(mb.Len()))
+	if !ok {
+		buf.ReleaseMulti(mb)
+		return newError("traffic quota exceeded")
+	}
+	if cutoff {
+		go w.onCutoff()
+	}
+	return w.writer.WriteMultiBuffer(mb)
+}
+
+// Close implements common.Closable.
+func (w *quotaWriter) Close() error {
+	return common.Close(w.writer)
+}
+
+// Interrupt implements common.Interruptible.
+func (w *quotaWriter) Interrupt() {
+	common.Interrupt(w.writer)
+}
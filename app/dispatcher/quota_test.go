@@ -0,0 +1,164 @@
+package dispatcher_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	. "v2ray.com/core/app/dispatcher"
+	app_stats "v2ray.com/core/app/stats"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/features/policy"
+	"v2ray.com/core/features/routing"
+	"v2ray.com/core/features/stats"
+	"v2ray.com/core/testing/mocks"
+	"v2ray.com/core/transport"
+)
+
+func TestUserQuota(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockOhm.EXPECT().GetDefaultHandler().Return(holdHandler{}).AnyTimes()
+
+	d := new(DefaultDispatcher)
+	common.Must(d.Init(&Config{}, mockOhm, routing.DefaultRouter{}, policy.DefaultManager{}, stats.NoopManager{}))
+
+	sub := d.SubscribeSessions()
+	defer sub.Close()
+
+	user := &protocol.MemoryUser{
+		Email: "alice@v2ray.com",
+		Quota: &protocol.Quota{Total: 8},
+	}
+	dispatch := func() (*transport.Link, error) {
+		ctx := session.ContextWithInbound(context.Background(), &session.Inbound{
+			Tag:  "in",
+			User: user,
+		})
+		return d.Dispatch(ctx, net.TCPDestination(net.DomainAddress("v2ray.com"), 443))
+	}
+
+	link, err := dispatch()
+	common.Must(err)
+	_, err = dispatch()
+	common.Must(err)
+	for i := 0; i < 2; i++ {
+		if e := waitSessionEvent(t, sub); e.Type != SessionStarted {
+			t.Fatal("expect start event, but got ", e)
+		}
+	}
+
+	b := buf.New()
+	b.WriteString("0123456789")
+	common.Must(link.Writer.WriteMultiBuffer(buf.MultiBuffer{b}))
+
+	for i := 0; i < 2; i++ {
+		if e := waitSessionEvent(t, sub); e.Type != SessionEnded {
+			t.Fatal("expect end event, but got ", e)
+		}
+	}
+
+	if _, err := dispatch(); err == nil {
+		t.Error("expect connection to be rejected after quota is used up")
+	}
+	status, found := d.GetUserQuota("alice@v2ray.com")
+	if !found || status.Used != 10 || !status.Exceeded {
+		t.Error("unexpected quota status: ", status)
+	}
+
+	d.SetUserQuota("alice@v2ray.com", &protocol.Quota{Total: 100})
+	_, err = dispatch()
+	common.Must(err)
+
+	d.SetUserQuota("alice@v2ray.com", &protocol.Quota{Total: 10})
+	if _, err := dispatch(); err == nil {
+		t.Error("expect connection to be rejected after quota is lowered")
+	}
+	if !d.ResetUserQuota("alice@v2ray.com") {
+		t.Error("expect quota to be reset")
+	}
+	_, err = dispatch()
+	common.Must(err)
+}
+
+func TestUserQuotaPersistence(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockOhm.EXPECT().GetDefaultHandler().Return(holdHandler{}).AnyTimes()
+
+	dir, err := ioutil.TempDir("", "v2ray-quota")
+	common.Must(err)
+	defer os.RemoveAll(dir)
+
+	statsConfig := &app_stats.Config{
+		Persistence: &app_stats.PersistenceConfig{
+			Path: filepath.Join(dir, "stats.json"),
+		},
+	}
+	user := &protocol.MemoryUser{
+		Email: "alice@v2ray.com",
+		Quota: &protocol.Quota{Total: 16, Periodic: 100, Period: 3600},
+	}
+	newDispatcher := func() (*DefaultDispatcher, *app_stats.Manager) {
+		sm, err := app_stats.NewManager(context.Background(), statsConfig)
+		common.Must(err)
+		common.Must(sm.Start())
+		d := new(DefaultDispatcher)
+		common.Must(d.Init(&Config{}, mockOhm, routing.DefaultRouter{}, policy.DefaultManager{}, sm))
+		return d, sm
+	}
+	dispatch := func(d *DefaultDispatcher) (*transport.Link, error) {
+		ctx := session.ContextWithInbound(context.Background(), &session.Inbound{
+			Tag:  "in",
+			User: user,
+		})
+		return d.Dispatch(ctx, net.TCPDestination(net.DomainAddress("v2ray.com"), 443))
+	}
+
+	d, sm := newDispatcher()
+	link, err := dispatch(d)
+	common.Must(err)
+	b := buf.New()
+	b.WriteString("0123456789")
+	common.Must(link.Writer.WriteMultiBuffer(buf.MultiBuffer{b}))
+	// Resetting counters by stats queries doesn't affect the usage, which is not kept in counters.
+	sm.SnapshotCounters(func(string) bool { return true }, true)
+	before, _ := d.GetUserQuota("alice@v2ray.com")
+	if before.Used != 10 || before.PeriodUsed != 10 {
+		t.Error("unexpected quota status after stats reset: ", before)
+	}
+	common.Must(sm.Close())
+
+	// After a restart, the usage is restored from the stats snapshot.
+	d, sm = newDispatcher()
+	defer sm.Close()
+	link, err = dispatch(d)
+	common.Must(err)
+	status, found := d.GetUserQuota("alice@v2ray.com")
+	if !found || status.Used != 10 || status.PeriodUsed != 10 || !status.PeriodStart.Equal(before.PeriodStart) || status.Exceeded {
+		t.Error("unexpected quota status after restart: ", status)
+	}
+
+	b = buf.New()
+	b.WriteString("0123456789")
+	common.Must(link.Writer.WriteMultiBuffer(buf.MultiBuffer{b}))
+	if _, err := dispatch(d); err == nil {
+		t.Error("expect connection to be rejected after quota is used up")
+	}
+	status, _ = d.GetUserQuota("alice@v2ray.com")
+	if status.Used != 20 || !status.Exceeded {
+		t.Error("unexpected quota status: ", status)
+	}
+}
@@ -0,0 +1,90 @@
+// +build !confonly
+
+package dispatcher
+
+import (
+	"sync"
+
+	"v2ray.com/core/common/ratelimit"
+	"v2ray.com/core/features/policy"
+	"v2ray.com/core/transport"
+)
+
+// sharedLimiter is a rate limiter in use by connections of the same user or inbound.
+type sharedLimiter struct {
+	limiter *ratelimit.Limiter
+	rate    uint64
+	burst   uint64
+	// refs is the number of connections using the limiter.
+	refs int
+}
+
+// limiterPool holds rate limiters shared by connections of the same user or inbound. A limiter is removed when the
+// last connection using it ends.
+type limiterPool struct {
+	access   sync.Mutex
+	limiters map[string]*sharedLimiter
+}
+
+// acquire returns the limiter of the given name, or a new limiter that is not shared if name is empty. The limiter is
+// rebuilt if its rate or burst has changed. The returned function releases the limiter when the connection ends.
+func (p *limiterPool) acquire(name string, rate uint64, burst uint64) (*ratelimit.Limiter, func()) {
+	if len(name) == 0 {
+		return ratelimit.New(rate, burst), func() {}
+	}
+
+	p.access.Lock()
+	defer p.access.Unlock()
+
+	if p.limiters == nil {
+		p.limiters = make(map[string]*sharedLimiter)
+	}
+	l, found := p.limiters[name]
+	if !found || l.rate != rate || l.burst != burst {
+		// Connections using the previous limiter keep it until they end.
+		l = &sharedLimiter{
+			limiter: ratelimit.New(rate, burst),
+			rate:    rate,
+			burst:   burst,
+		}
+		p.limiters[name] = l
+	}
+	l.refs++
+	return l.limiter, func() {
+		p.release(name, l)
+	}
+}
+
+func (p *limiterPool) release(name string, l *sharedLimiter) {
+	p.access.Lock()
+	defer p.access.Unlock()
+
+	l.refs--
+	if l.refs <= 0 && p.limiters[name] == l {
+		delete(p.limiters, name)
+	}
+}
+
+// limitLinks applies the rate limit on the writers of both directions of a connection. It returns the functions that
+// release the limiters when the connection ends.
+func (p *limiterPool) limitLinks(inboundLink *transport.Link, outboundLink *transport.Link, name string, limit policy.RateLimit) []func() {
+	var releases []func()
+	if limit.Uplink > 0 {
+		l, release := p.acquire(limiterName(name, "uplink"), limit.Uplink, limit.UplinkBurst)
+		inboundLink.Writer = ratelimit.NewWriter(inboundLink.Writer, l)
+		releases = append(releases, release)
+	}
+	if limit.Downlink > 0 {
+		l, release := p.acquire(limiterName(name, "downlink"), limit.Downlink, limit.DownlinkBurst)
+		outboundLink.Writer = ratelimit.NewWriter(outboundLink.Writer, l)
+		releases = append(releases, release)
+	}
+	return releases
+}
+
+func limiterName(name string, direction string) string {
+	if len(name) == 0 {
+		return ""
+	}
+	return name + ">>>" + direction
+}
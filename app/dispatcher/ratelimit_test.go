@@ -0,0 +1,47 @@
+package dispatcher
+
+import (
+	"testing"
+)
+
+func TestLimiterPool(t *testing.T) {
+	var p limiterPool
+
+	l1, release1 := p.acquire("user>>>alice@v2ray.com>>>uplink", 1000, 0)
+	l2, release2 := p.acquire("user>>>alice@v2ray.com>>>uplink", 1000, 0)
+	if l1 != l2 {
+		t.Error("expect connections of the same user to share the limiter")
+	}
+
+	// A changed rate takes effect on new connections with a rebuilt limiter.
+	l3, release3 := p.acquire("user>>>alice@v2ray.com>>>uplink", 2000, 0)
+	if l3 == l1 {
+		t.Error("expect limiter to be rebuilt after rate is changed")
+	}
+	l4, release4 := p.acquire("user>>>alice@v2ray.com>>>uplink", 2000, 4000)
+	if l4 == l3 {
+		t.Error("expect limiter to be rebuilt after burst is changed")
+	}
+
+	// Ending connections of previous limiters doesn't remove the current one.
+	release1()
+	release2()
+	release3()
+	if len(p.limiters) != 1 {
+		t.Error("expect 1 limiter, but got ", len(p.limiters))
+	}
+
+	release4()
+	if len(p.limiters) != 0 {
+		t.Error("expect limiter to be removed after the last connection ends, but got ", len(p.limiters))
+	}
+
+	if l, release := p.acquire("", 1000, 0); l == nil {
+		t.Error("expect limiter for anonymous connection")
+	} else {
+		release()
+	}
+	if len(p.limiters) != 0 {
+		t.Error("expect limiter of anonymous connection not to be shared")
+	}
+}
@@ -4,9 +4,11 @@ package dispatcher
 
 import (
 	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/protocol/bittorrent"
 	"v2ray.com/core/common/protocol/http"
 	"v2ray.com/core/common/protocol/tls"
+	"v2ray.com/core/features/dns"
 )
 
 type SniffResult interface {
@@ -53,3 +55,27 @@ func (s *Sniffer) Sniff(payload []byte) (SniffResult, error) {
 
 	return nil, errUnknownContent
 }
+
+type fakeDNSSniffResult struct {
+	domain string
+}
+
+func (fakeDNSSniffResult) Protocol() string {
+	return "fakedns"
+}
+
+func (r fakeDNSSniffResult) Domain() string {
+	return r.domain
+}
+
+// sniffFakeDNS restores the domain of a fake IP handed out by FakeDNS.
+func sniffFakeDNS(engine dns.FakeDNSEngine, destination net.Destination) (SniffResult, error) {
+	if engine == nil || !destination.Address.Family().IsIP() {
+		return nil, common.ErrNoClue
+	}
+	domain := engine.GetDomainFromFakeDNS(destination.Address)
+	if len(domain) == 0 {
+		return nil, errUnknownContent
+	}
+	return fakeDNSSniffResult{domain: domain}, nil
+}
@@ -0,0 +1,108 @@
+package dispatcher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	. "v2ray.com/core/app/dispatcher"
+	"v2ray.com/core/app/dns/fakedns"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/features/policy"
+	"v2ray.com/core/features/routing"
+	"v2ray.com/core/features/stats"
+	"v2ray.com/core/testing/mocks"
+	"v2ray.com/core/transport"
+)
+
+// targetHandler is an outbound handler that reports the target of each connection.
+type targetHandler struct {
+	targets chan net.Destination
+}
+
+func (targetHandler) Tag() string {
+	return "target"
+}
+
+func (h targetHandler) Dispatch(ctx context.Context, link *transport.Link) {
+	h.targets <- session.OutboundFromContext(ctx).Target
+	common.Interrupt(link.Reader)
+	common.Interrupt(link.Writer)
+}
+
+func (targetHandler) Start() error {
+	return nil
+}
+
+func (targetHandler) Close() error {
+	return nil
+}
+
+func TestFakeDNSSniffing(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	handler := targetHandler{targets: make(chan net.Destination, 1)}
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockOhm.EXPECT().GetDefaultHandler().Return(handler).AnyTimes()
+
+	engine, err := fakedns.NewHolder(&fakedns.FakeDnsPool{IpPool: "198.18.0.0/15"})
+	common.Must(err)
+	fakeIP := engine.GetFakeIPForDomain("www.v2ray.com")[0]
+
+	d := new(DefaultDispatcher)
+	common.Must(d.Init(&Config{}, mockOhm, routing.DefaultRouter{}, policy.DefaultManager{}, stats.NoopManager{}))
+	d.SetFakeDNS(engine)
+
+	testCases := []struct {
+		dest     net.Destination
+		override []string
+		expected net.Destination
+	}{
+		{
+			dest:     net.UDPDestination(fakeIP, 53),
+			override: []string{"fakedns"},
+			expected: net.UDPDestination(net.DomainAddress("www.v2ray.com"), 53),
+		},
+		{
+			dest:     net.UDPDestination(fakeIP, 53),
+			override: []string{"http", "tls"},
+			expected: net.UDPDestination(fakeIP, 53),
+		},
+		{
+			dest:     net.UDPDestination(net.ParseAddress("198.18.0.100"), 53),
+			override: []string{"fakedns"},
+			expected: net.UDPDestination(net.ParseAddress("198.18.0.100"), 53),
+		},
+		{
+			dest:     net.TCPDestination(fakeIP, 443),
+			override: []string{"fakedns"},
+			expected: net.TCPDestination(net.DomainAddress("www.v2ray.com"), 443),
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := session.ContextWithContent(context.Background(), &session.Content{
+			SniffingRequest: session.SniffingRequest{
+				Enabled:                        true,
+				OverrideDestinationForProtocol: tc.override,
+			},
+		})
+		link, err := d.Dispatch(ctx, tc.dest)
+		common.Must(err)
+
+		select {
+		case target := <-handler.targets:
+			if target != tc.expected {
+				t.Error("expect target ", tc.expected, ", but got ", target)
+			}
+		case <-time.After(time.Second * 2):
+			t.Fatal("timeout waiting for dispatch of ", tc.dest)
+		}
+		common.Interrupt(link.Writer)
+	}
+}
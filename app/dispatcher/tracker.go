@@ -0,0 +1,359 @@
+// +build !confonly
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/common/signal/pubsub"
+	"v2ray.com/core/features/policy"
+	"v2ray.com/core/features/stats"
+	"v2ray.com/core/transport/pipe"
+)
+
+const (
+	sessionEventTopic = "session"
+	// sessionChannelName is the name of the stats channel that receives all SessionEvents.
+	sessionChannelName = "dispatcher>>>session"
+)
+
+// connectionDurationBuckets are the bucket bounds of connection duration histograms, in seconds.
+var connectionDurationBuckets = []float64{1, 5, 10, 30, 60, 300, 600, 1800, 3600, 7200}
+
+// SessionEventType is the type of a SessionEvent.
+type SessionEventType int
+
+const (
+	// SessionStarted is published when a connection is routed to an outbound.
+	SessionStarted SessionEventType = iota
+	// SessionEnded is published when a routed connection is closed.
+	SessionEnded
+)
+
+// SessionInfo describes a connection dispatched by DefaultDispatcher.
+type SessionInfo struct {
+	ID            session.ID
+	InboundTag    string
+	User          string
+	Source        net.Destination
+	Destination   net.Destination
+	SniffedDomain string
+	Protocol      string
+	OutboundTag   string
+	// Start is the time when the connection is routed to an outbound. It is zero before that.
+	Start time.Time
+	// End is the time when the connection is closed. It is zero until the connection ends.
+	End time.Time
+	// Uplink and Downlink are the number of bytes transferred so far.
+	Uplink   int64
+	Downlink int64
+}
+
+// SessionEvent is published to subscribers of DefaultDispatcher on changes of connections.
+type SessionEvent struct {
+	Type SessionEventType
+	SessionInfo
+}
+
+// MarshalJSON implements json.Marshaler. It is used when the event is sent through a stats channel.
+func (e *SessionEvent) MarshalJSON() ([]byte, error) {
+	destString := func(dest net.Destination) string {
+		if !dest.IsValid() {
+			return ""
+		}
+		return dest.String()
+	}
+	timeUnix := func(t time.Time) int64 {
+		if t.IsZero() {
+			return 0
+		}
+		return t.Unix()
+	}
+	eventType := "start"
+	if e.Type == SessionEnded {
+		eventType = "end"
+	}
+	return json.Marshal(struct {
+		Type          string `json:"type"`
+		ID            uint32 `json:"id"`
+		InboundTag    string `json:"inboundTag,omitempty"`
+		User          string `json:"user,omitempty"`
+		Source        string `json:"source,omitempty"`
+		Destination   string `json:"destination,omitempty"`
+		SniffedDomain string `json:"sniffedDomain,omitempty"`
+		Protocol      string `json:"protocol,omitempty"`
+		OutboundTag   string `json:"outboundTag,omitempty"`
+		Start         int64  `json:"start,omitempty"`
+		End           int64  `json:"end,omitempty"`
+		Uplink        int64  `json:"uplink"`
+		Downlink      int64  `json:"downlink"`
+	}{
+		Type:          eventType,
+		ID:            uint32(e.ID),
+		InboundTag:    e.InboundTag,
+		User:          e.User,
+		Source:        destString(e.Source),
+		Destination:   destString(e.Destination),
+		SniffedDomain: e.SniffedDomain,
+		Protocol:      e.Protocol,
+		OutboundTag:   e.OutboundTag,
+		Start:         timeUnix(e.Start),
+		End:           timeUnix(e.End),
+		Uplink:        e.Uplink,
+		Downlink:      e.Downlink,
+	})
+}
+
+// sessionManager keeps track of active connections, and publishes their events.
+type sessionManager struct {
+	pub *pubsub.Service
+	// channel receives all events too, if the stats manager supports channels.
+	channel stats.Channel
+	stats   stats.Manager
+
+	access   sync.Mutex
+	sessions map[*sessionTracker]bool
+	// users indexes active connections by user email.
+	users map[string]map[*sessionTracker]bool
+}
+
+func newSessionManager(sm stats.Manager) *sessionManager {
+	m := &sessionManager{
+		pub:      pubsub.NewService(),
+		sessions: make(map[*sessionTracker]bool),
+		users:    make(map[string]map[*sessionTracker]bool),
+		stats:    sm,
+	}
+	if sm != nil {
+		m.channel, _ = stats.GetOrRegisterChannel(sm, sessionChannelName)
+	}
+	return m
+}
+
+// checkUserLimit returns an error if adding a connection from the given source would exceed the limit of the user.
+// It must be called with m.access locked.
+func (m *sessionManager) checkUserLimit(user string, source net.Destination, limit policy.UserLimit) error {
+	sessions := m.users[user]
+	if limit.MaxConnections > 0 && uint32(len(sessions)) >= limit.MaxConnections {
+		return newError("user ", user, " exceeds the limit of ", limit.MaxConnections, " connections")
+	}
+	if limit.MaxSourceIPs > 0 && source.IsValid() {
+		ip := source.Address.String()
+		ips := make(map[string]bool)
+		for t := range sessions {
+			if t.info.Source.IsValid() {
+				ips[t.info.Source.Address.String()] = true
+			}
+		}
+		if !ips[ip] && uint32(len(ips)) >= limit.MaxSourceIPs {
+			return newError("user ", user, " exceeds the limit of ", limit.MaxSourceIPs, " source IPs")
+		}
+	}
+	return nil
+}
+
+// add registers the connection as active, unless it exceeds the limit of its user.
+func (m *sessionManager) add(t *sessionTracker, limit policy.UserLimit) error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if user := t.info.User; len(user) > 0 {
+		if err := m.checkUserLimit(user, t.info.Source, limit); err != nil {
+			return err
+		}
+		if m.users[user] == nil {
+			m.users[user] = make(map[*sessionTracker]bool)
+		}
+		m.users[user][t] = true
+	}
+	m.sessions[t] = true
+	return nil
+}
+
+func (m *sessionManager) remove(t *sessionTracker) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	delete(m.sessions, t)
+	if user := t.info.User; len(user) > 0 {
+		delete(m.users[user], t)
+		if len(m.users[user]) == 0 {
+			delete(m.users, user)
+		}
+	}
+}
+
+func (m *sessionManager) list() []*sessionTracker {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	sessions := make([]*sessionTracker, 0, len(m.sessions))
+	for t := range m.sessions {
+		sessions = append(sessions, t)
+	}
+	return sessions
+}
+
+// sessionTracker counts traffic of a connection and publishes its events.
+type sessionTracker struct {
+	// uplink and downlink are accessed atomically, and are kept first for alignment.
+	uplink   int64
+	downlink int64
+
+	sync.Mutex
+	info    SessionInfo
+	started bool
+	ended   bool
+	manager *sessionManager
+
+	// online tracks the source IP of the user, if enabled.
+	online   stats.OnlineMap
+	onlineIP string
+	// inboundGauge counts active connections of the inbound.
+	inboundGauge stats.Gauge
+	// releaseLimiters release the shared rate limiters used by the connection.
+	releaseLimiters []func()
+
+	// uplinkPipe and downlinkPipe are the readers of both directions of the connection.
+	uplinkPipe   *pipe.Reader
+	downlinkPipe *pipe.Reader
+}
+
+// snapshot returns a copy of the info with current traffic. It must be called with t locked.
+func (t *sessionTracker) snapshot() SessionInfo {
+	info := t.info
+	info.Uplink = atomic.LoadInt64(&t.uplink)
+	info.Downlink = atomic.LoadInt64(&t.downlink)
+	return info
+}
+
+func (t *sessionTracker) publish(eventType SessionEventType) {
+	event := &SessionEvent{
+		Type:        eventType,
+		SessionInfo: t.snapshot(),
+	}
+	t.manager.pub.Publish(sessionEventTopic, event)
+	if t.manager.channel != nil {
+		t.manager.channel.Publish(event)
+	}
+}
+
+// getInfo returns the current info of the connection.
+func (t *sessionTracker) getInfo() SessionInfo {
+	t.Lock()
+	defer t.Unlock()
+	return t.snapshot()
+}
+
+func (t *sessionTracker) setSniffedDomain(domain string) {
+	t.Lock()
+	t.info.SniffedDomain = domain
+	t.Unlock()
+}
+
+// setOnline marks the source IP of the connection online, until the connection ends.
+func (t *sessionTracker) setOnline(om stats.OnlineMap, ip string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.ended {
+		return
+	}
+	om.AddIP(ip)
+	t.online = om
+	t.onlineIP = ip
+}
+
+// start publishes SessionStarted, after the connection is routed to the given outbound.
+func (t *sessionTracker) start(outboundTag string, destination net.Destination, protocol string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.started || t.ended {
+		return
+	}
+	t.started = true
+	t.info.OutboundTag = outboundTag
+	t.info.Destination = destination
+	t.info.Protocol = protocol
+	t.info.Start = time.Now()
+	t.publish(SessionStarted)
+}
+
+// end removes the connection from active ones, and publishes SessionEnded if it has been started.
+func (t *sessionTracker) end() {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.ended {
+		return
+	}
+	t.ended = true
+	t.manager.remove(t)
+	if t.online != nil {
+		t.online.RemoveIP(t.onlineIP)
+	}
+	if t.inboundGauge != nil {
+		t.inboundGauge.Add(-1)
+	}
+	for _, release := range t.releaseLimiters {
+		release()
+	}
+	if !t.started {
+		return
+	}
+	t.info.End = time.Now()
+	t.observeDuration()
+	t.publish(SessionEnded)
+}
+
+// observeDuration records the duration of the connection into the histogram of its outbound. It must be called with t locked.
+func (t *sessionTracker) observeDuration() {
+	if t.manager.stats == nil || len(t.info.OutboundTag) == 0 {
+		return
+	}
+	name := "outbound>>>" + t.info.OutboundTag + ">>>connection_duration_seconds"
+	if h, _ := stats.GetOrRegisterHistogram(t.manager.stats, name, connectionDurationBuckets); h != nil {
+		h.Observe(t.info.End.Sub(t.info.Start).Seconds())
+	}
+}
+
+// interrupt terminates both directions of the connection.
+func (t *sessionTracker) interrupt() {
+	t.uplinkPipe.Interrupt()
+	t.downlinkPipe.Interrupt()
+}
+
+// sessionCountWriter counts bytes written into a connection.
+// If onClose is set, it is called when the writer is closed or interrupted.
+type sessionCountWriter struct {
+	counter *int64
+	writer  buf.Writer
+	onClose func()
+}
+
+func (w *sessionCountWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	atomic.AddInt64(w.counter, int64(mb.Len()))
+	return w.writer.WriteMultiBuffer(mb)
+}
+
+func (w *sessionCountWriter) Close() error {
+	if w.onClose != nil {
+		w.onClose()
+	}
+	return common.Close(w.writer)
+}
+
+func (w *sessionCountWriter) Interrupt() {
+	if w.onClose != nil {
+		w.onClose()
+	}
+	common.Interrupt(w.writer)
+}
@@ -0,0 +1,338 @@
+package dispatcher_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	. "v2ray.com/core/app/dispatcher"
+	app_stats "v2ray.com/core/app/stats"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/common/signal/pubsub"
+	"v2ray.com/core/features/policy"
+	"v2ray.com/core/features/routing"
+	"v2ray.com/core/features/stats"
+	"v2ray.com/core/testing/mocks"
+	"v2ray.com/core/transport"
+)
+
+// echoHandler is an outbound handler that echoes back the first payload and then closes the connection.
+type echoHandler struct{}
+
+func (echoHandler) Tag() string {
+	return "echo"
+}
+
+func (echoHandler) Dispatch(ctx context.Context, link *transport.Link) {
+	mb, err := link.Reader.ReadMultiBuffer()
+	if err == nil {
+		common.Must(link.Writer.WriteMultiBuffer(mb))
+	}
+	common.Close(link.Writer)
+}
+
+func (echoHandler) Start() error {
+	return nil
+}
+
+func (echoHandler) Close() error {
+	return nil
+}
+
+// holdHandler is an outbound handler that keeps the connection open until it is interrupted.
+type holdHandler struct{}
+
+func (holdHandler) Tag() string {
+	return "hold"
+}
+
+func (holdHandler) Dispatch(ctx context.Context, link *transport.Link) {
+	for {
+		if _, err := link.Reader.ReadMultiBuffer(); err != nil {
+			break
+		}
+	}
+	common.Interrupt(link.Writer)
+}
+
+func (holdHandler) Start() error {
+	return nil
+}
+
+func (holdHandler) Close() error {
+	return nil
+}
+
+func waitSessionEvent(t *testing.T, sub *pubsub.Subscriber) *SessionEvent {
+	select {
+	case msg := <-sub.Wait():
+		return msg.(*SessionEvent)
+	case <-time.After(time.Second * 2):
+		t.Fatal("timeout waiting for session event")
+		return nil
+	}
+}
+
+func TestSessionEvents(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockOhm.EXPECT().GetDefaultHandler().Return(echoHandler{})
+
+	d := new(DefaultDispatcher)
+	common.Must(d.Init(&Config{}, mockOhm, routing.DefaultRouter{}, policy.DefaultManager{}, stats.NoopManager{}))
+
+	sub := d.SubscribeSessions()
+	defer sub.Close()
+
+	ctx := session.ContextWithID(context.Background(), session.NewID())
+	ctx = session.ContextWithInbound(ctx, &session.Inbound{
+		Tag:    "in",
+		Source: net.TCPDestination(net.LocalHostIP, 10000),
+	})
+	dest := net.TCPDestination(net.DomainAddress("v2ray.com"), 443)
+	link, err := d.Dispatch(ctx, dest)
+	common.Must(err)
+
+	b := buf.New()
+	b.WriteString("v2ray")
+	common.Must(link.Writer.WriteMultiBuffer(buf.MultiBuffer{b}))
+
+	start := waitSessionEvent(t, sub)
+	if start.Type != SessionStarted || start.InboundTag != "in" || start.OutboundTag != "echo" || start.Destination != dest {
+		t.Error("unexpected start event: ", start)
+	}
+
+	end := waitSessionEvent(t, sub)
+	if end.Type != SessionEnded || end.End.IsZero() {
+		t.Error("unexpected end event: ", end)
+	}
+	if end.Uplink != 5 || end.Downlink != 5 {
+		t.Error("expect 5 bytes up and down, but got ", end.Uplink, " and ", end.Downlink)
+	}
+}
+
+func TestCloseSessions(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockOhm.EXPECT().GetDefaultHandler().Return(holdHandler{}).Times(2)
+
+	d := new(DefaultDispatcher)
+	common.Must(d.Init(&Config{}, mockOhm, routing.DefaultRouter{}, policy.DefaultManager{}, stats.NoopManager{}))
+
+	sub := d.SubscribeSessions()
+	defer sub.Close()
+
+	for _, email := range []string{"alice@v2ray.com", "bob@v2ray.com"} {
+		ctx := session.ContextWithInbound(context.Background(), &session.Inbound{
+			Tag:  "in",
+			User: &protocol.MemoryUser{Email: email},
+		})
+		_, err := d.Dispatch(ctx, net.TCPDestination(net.DomainAddress("v2ray.com"), 443))
+		common.Must(err)
+		if e := waitSessionEvent(t, sub); e.Type != SessionStarted {
+			t.Fatal("expect start event, but got ", e)
+		}
+	}
+
+	if sessions := d.ListSessions(); len(sessions) != 2 {
+		t.Fatal("expect 2 sessions, but got ", len(sessions))
+	}
+
+	closed := d.CloseSessions(func(info *SessionInfo) bool {
+		return info.User == "alice@v2ray.com"
+	})
+	if closed != 1 {
+		t.Error("expect 1 session closed, but got ", closed)
+	}
+	if e := waitSessionEvent(t, sub); e.Type != SessionEnded || e.User != "alice@v2ray.com" {
+		t.Error("unexpected event: ", e)
+	}
+
+	sessions := d.ListSessions()
+	if len(sessions) != 1 || sessions[0].User != "bob@v2ray.com" {
+		t.Error("unexpected sessions: ", sessions)
+	}
+}
+
+type limitedPolicyManager struct {
+	policy.DefaultManager
+	limit policy.UserLimit
+}
+
+func (m limitedPolicyManager) ForLevel(level uint32) policy.Session {
+	p := m.DefaultManager.ForLevel(level)
+	p.UserLimit = m.limit
+	return p
+}
+
+func TestUserLimit(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockOhm.EXPECT().GetDefaultHandler().Return(holdHandler{}).AnyTimes()
+
+	dispatch := func(d *DefaultDispatcher, email string, ip string) error {
+		ctx := session.ContextWithInbound(context.Background(), &session.Inbound{
+			Source: net.TCPDestination(net.ParseAddress(ip), 10000),
+			User:   &protocol.MemoryUser{Email: email},
+		})
+		_, err := d.Dispatch(ctx, net.TCPDestination(net.DomainAddress("v2ray.com"), 443))
+		return err
+	}
+
+	d := new(DefaultDispatcher)
+	common.Must(d.Init(&Config{}, mockOhm, routing.DefaultRouter{}, limitedPolicyManager{limit: policy.UserLimit{MaxConnections: 2}}, stats.NoopManager{}))
+	common.Must(dispatch(d, "alice@v2ray.com", "10.0.0.1"))
+	common.Must(dispatch(d, "alice@v2ray.com", "10.0.0.2"))
+	if err := dispatch(d, "alice@v2ray.com", "10.0.0.1"); err == nil {
+		t.Error("expect the 3rd connection to be rejected")
+	}
+	common.Must(dispatch(d, "bob@v2ray.com", "10.0.0.1"))
+
+	d = new(DefaultDispatcher)
+	common.Must(d.Init(&Config{}, mockOhm, routing.DefaultRouter{}, limitedPolicyManager{limit: policy.UserLimit{MaxSourceIPs: 1}}, stats.NoopManager{}))
+	common.Must(dispatch(d, "alice@v2ray.com", "10.0.0.1"))
+	common.Must(dispatch(d, "alice@v2ray.com", "10.0.0.1"))
+	if err := dispatch(d, "alice@v2ray.com", "10.0.0.2"); err == nil {
+		t.Error("expect connection from another IP to be rejected")
+	}
+
+	d.CloseSessions(func(*SessionInfo) bool { return true })
+	for i := 0; i < 100 && len(d.ListSessions()) > 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	common.Must(dispatch(d, "alice@v2ray.com", "10.0.0.2"))
+}
+
+func TestSessionChannel(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockOhm.EXPECT().GetDefaultHandler().Return(echoHandler{})
+
+	sm, err := app_stats.NewManager(context.Background(), &app_stats.Config{})
+	common.Must(err)
+
+	d := new(DefaultDispatcher)
+	common.Must(d.Init(&Config{}, mockOhm, routing.DefaultRouter{}, policy.DefaultManager{}, sm))
+
+	channel := sm.GetChannel("dispatcher>>>session")
+	if channel == nil {
+		t.Fatal("session channel is not registered")
+	}
+	sub, err := channel.Subscribe()
+	common.Must(err)
+
+	ctx := session.ContextWithInbound(context.Background(), &session.Inbound{Tag: "in"})
+	_, err = d.Dispatch(ctx, net.TCPDestination(net.DomainAddress("v2ray.com"), 443))
+	common.Must(err)
+
+	select {
+	case msg := <-sub:
+		b, err := json.Marshal(msg)
+		common.Must(err)
+		if s := string(b); !strings.Contains(s, `"type":"start"`) || !strings.Contains(s, `"destination":"tcp:v2ray.com:443"`) {
+			t.Error("unexpected message: ", s)
+		}
+	case <-time.After(time.Second * 2):
+		t.Fatal("timeout waiting for session event")
+	}
+}
+
+type onlineStatsPolicyManager struct {
+	policy.DefaultManager
+}
+
+func (m onlineStatsPolicyManager) ForLevel(level uint32) policy.Session {
+	p := m.DefaultManager.ForLevel(level)
+	p.Stats.UserOnline = true
+	return p
+}
+
+func TestUserOnline(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockOhm.EXPECT().GetDefaultHandler().Return(holdHandler{}).AnyTimes()
+
+	sm, err := app_stats.NewManager(context.Background(), &app_stats.Config{})
+	common.Must(err)
+
+	d := new(DefaultDispatcher)
+	common.Must(d.Init(&Config{}, mockOhm, routing.DefaultRouter{}, onlineStatsPolicyManager{}, sm))
+
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+		ctx := session.ContextWithInbound(context.Background(), &session.Inbound{
+			Source: net.TCPDestination(net.ParseAddress(ip), 10000),
+			User:   &protocol.MemoryUser{Email: "alice@v2ray.com"},
+		})
+		_, err := d.Dispatch(ctx, net.TCPDestination(net.DomainAddress("v2ray.com"), 443))
+		common.Must(err)
+	}
+
+	om := sm.GetOnlineMap("user>>>alice@v2ray.com>>>online")
+	if om == nil {
+		t.Fatal("online map is not registered")
+	}
+	ips := om.IPTimeMap()
+	if _, found := ips["10.0.0.2"]; len(ips) != 2 || !found {
+		t.Error("unexpected online IPs: ", ips)
+	}
+}
+
+func TestConnectionMetrics(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockOhm.EXPECT().GetDefaultHandler().Return(echoHandler{})
+
+	sm, err := app_stats.NewManager(context.Background(), &app_stats.Config{})
+	common.Must(err)
+
+	d := new(DefaultDispatcher)
+	common.Must(d.Init(&Config{}, mockOhm, routing.DefaultRouter{}, policy.DefaultManager{}, sm))
+
+	sub := d.SubscribeSessions()
+	defer sub.Close()
+
+	ctx := session.ContextWithInbound(context.Background(), &session.Inbound{Tag: "in"})
+	link, err := d.Dispatch(ctx, net.TCPDestination(net.DomainAddress("v2ray.com"), 443))
+	common.Must(err)
+
+	gauge := sm.GetGauge("inbound>>>in>>>connections")
+	if gauge == nil || gauge.Value() != 1 {
+		t.Fatal("expect 1 active inbound connection")
+	}
+
+	b := buf.New()
+	b.WriteString("v2ray")
+	common.Must(link.Writer.WriteMultiBuffer(buf.MultiBuffer{b}))
+	waitSessionEvent(t, sub)
+	if e := waitSessionEvent(t, sub); e.Type != SessionEnded {
+		t.Fatal("expect end event, but got ", e)
+	}
+
+	if v := gauge.Value(); v != 0 {
+		t.Error("expect no active inbound connection, but got ", v)
+	}
+	h := sm.GetHistogram("outbound>>>echo>>>connection_duration_seconds")
+	if h == nil || h.Snapshot().Count != 1 {
+		t.Error("expect connection duration to be recorded")
+	}
+}
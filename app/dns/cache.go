@@ -0,0 +1,212 @@
+// +build !confonly
+
+package dns
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/task"
+	"v2ray.com/core/features/stats"
+)
+
+type cacheEntry struct {
+	key    string
+	record record
+	// refreshAt is the time when a refresh of the expired record is started.
+	refreshAt time.Time
+}
+
+// Cache is a cache of DNS answers shared by name servers. Records are bounded in number and evicted in LRU order.
+type Cache struct {
+	sync.Mutex
+	size       int
+	minTTL     time.Duration
+	maxTTL     time.Duration
+	serveStale bool
+	staleTTL   time.Duration
+
+	// lru holds entries from the most recently used to the least recently used.
+	lru     *list.List
+	entries map[string]*list.Element
+	cleanup *task.Periodic
+
+	hits   stats.Counter
+	misses stats.Counter
+}
+
+// NewCache creates a Cache with the given config.
+func NewCache(config *CacheConfig) *Cache {
+	c := &Cache{
+		size:     4096,
+		staleTTL: time.Hour,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+	if config != nil {
+		if config.Size > 0 {
+			c.size = int(config.Size)
+		}
+		c.minTTL = time.Duration(config.MinTtl) * time.Second
+		c.maxTTL = time.Duration(config.MaxTtl) * time.Second
+		c.serveStale = config.ServeStale
+		if config.StaleTtl > 0 {
+			c.staleTTL = time.Duration(config.StaleTtl) * time.Second
+		}
+	}
+	c.cleanup = &task.Periodic{
+		Interval: time.Minute,
+		Execute:  c.Cleanup,
+	}
+	return c
+}
+
+// SetCounters sets the counters of cache hits and misses. Either may be nil.
+func (c *Cache) SetCounters(hits, misses stats.Counter) {
+	c.Lock()
+	defer c.Unlock()
+	c.hits = hits
+	c.misses = misses
+}
+
+// usableUntil returns the time until which the record can be served, including the period of serving stale.
+func (c *Cache) usableUntil(r *IPRecord) time.Time {
+	if c.serveStale {
+		return r.Expire.Add(c.staleTTL)
+	}
+	return r.Expire
+}
+
+// clamp applies TTL bounds to a record that has just been received.
+func (c *Cache) clamp(r *IPRecord, now time.Time) {
+	if r == nil {
+		return
+	}
+	ttl := r.Expire.Sub(now)
+	if c.minTTL > 0 && ttl < c.minTTL {
+		ttl = c.minTTL
+	}
+	if c.maxTTL > 0 && ttl > c.maxTTL {
+		ttl = c.maxTTL
+	}
+	r.Expire = now.Add(ttl)
+}
+
+// Update merges new records of the domain into the cache. key identifies the name server and the domain.
+func (c *Cache) Update(key string, newRec record) {
+	now := time.Now()
+	c.clamp(newRec.A, now)
+	c.clamp(newRec.AAAA, now)
+
+	c.Lock()
+	elem, found := c.entries[key]
+	if !found {
+		elem = c.lru.PushFront(&cacheEntry{key: key})
+		c.entries[key] = elem
+	} else {
+		c.lru.MoveToFront(elem)
+	}
+	e := elem.Value.(*cacheEntry)
+	if isNewer(e.record.A, newRec.A) {
+		e.record.A = newRec.A
+	}
+	if isNewer(e.record.AAAA, newRec.AAAA) {
+		e.record.AAAA = newRec.AAAA
+	}
+	e.refreshAt = time.Time{}
+
+	for c.lru.Len() > c.size {
+		evicted := c.lru.Remove(c.lru.Back()).(*cacheEntry)
+		delete(c.entries, evicted.key)
+	}
+	c.Unlock()
+
+	common.Must(c.cleanup.Start())
+}
+
+// Lookup returns IPs of the domain in the cache along with their remaining TTL, or errRecordNotFound if any
+// requested IP family is not cached. refresh is true if a stale record is served, and the caller is expected to
+// refresh it.
+func (c *Cache) Lookup(key string, option IPOption) (ips []net.IP, ttl uint32, refresh bool, err error) {
+	now := time.Now()
+
+	c.Lock()
+	defer c.Unlock()
+
+	elem, found := c.entries[key]
+	if !found {
+		c.miss()
+		return nil, 0, false, errRecordNotFound
+	}
+	e := elem.Value.(*cacheEntry)
+
+	stale := false
+	for _, r := range e.record.selected(option) {
+		if r == nil || !c.usableUntil(r).After(now) {
+			c.miss()
+			return nil, 0, false, errRecordNotFound
+		}
+		if !r.Expire.After(now) {
+			stale = true
+		}
+	}
+
+	c.lru.MoveToFront(elem)
+	c.hit()
+	// A refresh is considered failed if no answer is received in time, and another one can be started.
+	if stale && now.Sub(e.refreshAt) > time.Second*8 {
+		e.refreshAt = now
+		refresh = true
+	}
+	ips, ttl, err = e.record.getIPs(option)
+	return ips, ttl, refresh, err
+}
+
+func (c *Cache) hit() {
+	if c.hits != nil {
+		c.hits.Add(1)
+	}
+}
+
+func (c *Cache) miss() {
+	if c.misses != nil {
+		c.misses.Add(1)
+	}
+}
+
+// Len returns the number of domains in the cache.
+func (c *Cache) Len() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.lru.Len()
+}
+
+// Cleanup removes records that can no longer be served.
+func (c *Cache) Cleanup() error {
+	now := time.Now()
+	c.Lock()
+	defer c.Unlock()
+
+	if c.lru.Len() == 0 {
+		return newError("nothing to do. stopping...")
+	}
+
+	for key, elem := range c.entries {
+		e := elem.Value.(*cacheEntry)
+		if e.record.A != nil && !c.usableUntil(e.record.A).After(now) {
+			e.record.A = nil
+		}
+		if e.record.AAAA != nil && !c.usableUntil(e.record.AAAA).After(now) {
+			e.record.AAAA = nil
+		}
+		if e.record.A == nil && e.record.AAAA == nil {
+			c.lru.Remove(elem)
+			delete(c.entries, key)
+		}
+	}
+
+	return nil
+}
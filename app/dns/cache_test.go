@@ -0,0 +1,108 @@
+// +build !confonly
+
+package dns
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"v2ray.com/core/app/stats"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+)
+
+func newTestRecord(ip string, ttl time.Duration) *IPRecord {
+	return &IPRecord{
+		IP:     []net.Address{net.ParseAddress(ip)},
+		Expire: time.Now().Add(ttl),
+		RCode:  dnsmessage.RCodeSuccess,
+	}
+}
+
+func TestCacheLookup(t *testing.T) {
+	c := NewCache(&CacheConfig{Size: 2})
+	hits, misses := new(stats.Counter), new(stats.Counter)
+	c.SetCounters(hits, misses)
+
+	ipv4 := IPOption{IPv4Enable: true}
+	dual := IPOption{IPv4Enable: true, IPv6Enable: true}
+
+	if _, _, _, err := c.Lookup("a", ipv4); err != errRecordNotFound {
+		t.Fatal("expect record not found, but got ", err)
+	}
+
+	c.Update("a", record{A: newTestRecord("1.1.1.1", time.Minute)})
+	ips, _, refresh, err := c.Lookup("a", ipv4)
+	if err != nil || refresh || len(ips) != 1 || ips[0].String() != "1.1.1.1" {
+		t.Fatal("unexpected result: ", ips, refresh, err)
+	}
+	if _, _, _, err := c.Lookup("a", dual); err != errRecordNotFound {
+		t.Error("expect record not found for missing AAAA record, but got ", err)
+	}
+
+	c.Update("b", record{A: newTestRecord("2.2.2.2", time.Minute)})
+	c.Lookup("a", ipv4) // nolint: errcheck
+	c.Update("c", record{A: newTestRecord("3.3.3.3", time.Minute)})
+	if c.Len() != 2 {
+		t.Error("expect 2 records, but got ", c.Len())
+	}
+	if _, _, _, err := c.Lookup("b", ipv4); err != errRecordNotFound {
+		t.Error("expect least recently used record to be evicted, but got ", err)
+	}
+
+	if hits.Value() != 2 || misses.Value() != 3 {
+		t.Error("unexpected hits ", hits.Value(), " and misses ", misses.Value())
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	c := NewCache(&CacheConfig{MinTtl: 60, MaxTtl: 120})
+	ipv4 := IPOption{IPv4Enable: true}
+
+	c.Update("min", record{A: newTestRecord("1.1.1.1", 0)})
+	if _, _, _, err := c.Lookup("min", ipv4); err != nil {
+		t.Error("expect record with TTL raised to min TTL, but got ", err)
+	}
+
+	rec := newTestRecord("2.2.2.2", time.Hour)
+	c.Update("max", record{A: rec})
+	if ttl := time.Until(rec.Expire); ttl > time.Second*120 {
+		t.Error("expect TTL lowered to max TTL, but got ", ttl)
+	}
+}
+
+func TestCacheServeStale(t *testing.T) {
+	ipv4 := IPOption{IPv4Enable: true}
+
+	c := NewCache(nil)
+	c.Update("a", record{A: newTestRecord("1.1.1.1", -time.Second)})
+	if _, _, _, err := c.Lookup("a", ipv4); err != errRecordNotFound {
+		t.Error("expect expired record not to be served, but got ", err)
+	}
+
+	c = NewCache(&CacheConfig{ServeStale: true, StaleTtl: 60})
+	c.Update("a", record{A: newTestRecord("1.1.1.1", -time.Second)})
+	ips, _, refresh, err := c.Lookup("a", ipv4)
+	if err != nil || !refresh || len(ips) != 1 {
+		t.Fatal("expect stale record to be served with refresh, but got ", ips, refresh, err)
+	}
+	if _, _, refresh, _ := c.Lookup("a", ipv4); refresh {
+		t.Error("expect only one refresh at a time")
+	}
+
+	c.Update("a", record{A: newTestRecord("2.2.2.2", time.Minute)})
+	ips, _, refresh, err = c.Lookup("a", ipv4)
+	if err != nil || refresh || ips[0].String() != "2.2.2.2" {
+		t.Error("expect refreshed record, but got ", ips, refresh, err)
+	}
+
+	c.Update("b", record{A: newTestRecord("1.1.1.1", -time.Minute*2)})
+	if _, _, _, err := c.Lookup("b", ipv4); err != errRecordNotFound {
+		t.Error("expect record beyond stale TTL not to be served, but got ", err)
+	}
+	common.Must(c.Cleanup())
+	if c.Len() != 1 {
+		t.Error("expect 1 record after cleanup, but got ", c.Len())
+	}
+}
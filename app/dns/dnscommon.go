@@ -3,6 +3,7 @@
 package dns
 
 import (
+	"context"
 	"encoding/binary"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/errors"
 	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/signal/pubsub"
 	dns_feature "v2ray.com/core/features/dns"
 )
 
@@ -26,6 +28,48 @@ type record struct {
 	AAAA *IPRecord
 }
 
+// selected returns the records of the IP families enabled in the option.
+func (r record) selected(option IPOption) []*IPRecord {
+	var recs []*IPRecord
+	if option.IPv4Enable {
+		recs = append(recs, r.A)
+	}
+	if option.IPv6Enable {
+		recs = append(recs, r.AAAA)
+	}
+	return recs
+}
+
+// getIPs returns IPs of the IP families enabled in the option regardless of expiry, and the least remaining TTL
+// of the records that have them.
+func (r record) getIPs(option IPOption) ([]net.IP, uint32, error) {
+	var ips []net.Address
+	var ttl uint32
+	var lastErr error
+	for _, rec := range r.selected(option) {
+		a, err := rec.getIPs()
+		if err != nil {
+			lastErr = err
+		}
+		if len(a) > 0 {
+			if t := rec.remainingTTL(); ttl == 0 || t < ttl {
+				ttl = t
+			}
+		}
+		ips = append(ips, a...)
+	}
+
+	if len(ips) > 0 {
+		return toNetIP(ips), ttl, nil
+	}
+
+	if lastErr != nil {
+		return nil, 0, lastErr
+	}
+
+	return nil, 0, dns_feature.ErrEmptyResponse
+}
+
 // IPRecord is a cacheable item for a resolved domain
 type IPRecord struct {
 	ReqID  uint16
@@ -35,7 +79,7 @@ type IPRecord struct {
 }
 
 func (r *IPRecord) getIPs() ([]net.Address, error) {
-	if r == nil || r.Expire.Before(time.Now()) {
+	if r == nil {
 		return nil, errRecordNotFound
 	}
 	if r.RCode != dnsmessage.RCodeSuccess {
@@ -44,6 +88,16 @@ func (r *IPRecord) getIPs() ([]net.Address, error) {
 	return r.IP, nil
 }
 
+// remainingTTL returns the TTL in seconds until the record expires. An expired record served as stale has a TTL
+// of 1 second, so that it is not cached further.
+func (r *IPRecord) remainingTTL() uint32 {
+	ttl := time.Until(r.Expire) / time.Second
+	if ttl < 1 {
+		return 1
+	}
+	return uint32(ttl)
+}
+
 func isNewer(baseRec *IPRecord, newRec *IPRecord) bool {
 	if newRec == nil {
 		return false
@@ -58,6 +112,10 @@ var (
 	errRecordNotFound = errors.New("record not found")
 )
 
+// defaultTTL is the TTL in seconds of answers whose TTL is unknown, such as those from static hosts and the system
+// resolver.
+const defaultTTL uint32 = 600
+
 type dnsRequest struct {
 	reqType dnsmessage.Type
 	domain  string
@@ -165,6 +223,71 @@ func buildReqMsgs(domain string, option IPOption, reqIDGen func() uint16, reqOpt
 	return reqs
 }
 
+// buildRecordQuery builds a query of the given type for the domain.
+func buildRecordQuery(domain string, qType dnsmessage.Type, id uint16, reqOpts *dnsmessage.Resource) (*dnsmessage.Message, error) {
+	name, err := dnsmessage.NewName(Fqdn(domain))
+	if err != nil {
+		return nil, newError("invalid domain name: ", domain).Base(err)
+	}
+	msg := new(dnsmessage.Message)
+	msg.Header.ID = id
+	msg.Header.RecursionDesired = true
+	msg.Questions = []dnsmessage.Question{{
+		Name:  name,
+		Type:  qType,
+		Class: dnsmessage.ClassINET,
+	}}
+	if reqOpts != nil {
+		msg.Additionals = append(msg.Additionals, *reqOpts)
+	}
+	return msg, nil
+}
+
+// parseRecords parses answers in the returned payload. Answers of types that are not understood are skipped.
+func parseRecords(payload []byte) ([]dnsmessage.Resource, error) {
+	var parser dnsmessage.Parser
+	h, err := parser.Start(payload)
+	if err != nil {
+		return nil, newError("failed to parse DNS response").Base(err).AtWarning()
+	}
+	if err := parser.SkipAllQuestions(); err != nil {
+		return nil, newError("failed to skip questions in DNS response").Base(err).AtWarning()
+	}
+
+	var answers []dnsmessage.Resource
+	for {
+		ah, err := parser.AnswerHeader()
+		if err == dnsmessage.ErrSectionDone {
+			break
+		}
+		if err != nil {
+			return nil, newError("failed to parse answer section").Base(err).AtWarning()
+		}
+
+		switch ah.Type {
+		case dnsmessage.TypeA, dnsmessage.TypeAAAA, dnsmessage.TypeCNAME, dnsmessage.TypeMX, dnsmessage.TypeNS,
+			dnsmessage.TypePTR, dnsmessage.TypeSOA, dnsmessage.TypeTXT, dnsmessage.TypeSRV:
+			answer, err := parser.Answer()
+			if err != nil {
+				return nil, newError("failed to parse ", ah.Type, " record for domain: ", ah.Name).Base(err).AtWarning()
+			}
+			answers = append(answers, answer)
+		default:
+			if err := parser.SkipAnswer(); err != nil {
+				return nil, newError("failed to skip answer").Base(err).AtWarning()
+			}
+		}
+	}
+
+	if h.RCode != dnsmessage.RCodeSuccess {
+		return nil, dns_feature.RCodeError(h.RCode)
+	}
+	if len(answers) == 0 {
+		return nil, dns_feature.ErrEmptyResponse
+	}
+	return answers, nil
+}
+
 // parseResponse parse DNS answers from the returned payload
 func parseResponse(payload []byte) (*IPRecord, error) {
 	var parser dnsmessage.Parser
@@ -178,9 +301,8 @@ func parseResponse(payload []byte) (*IPRecord, error) {
 
 	now := time.Now()
 	ipRecord := &IPRecord{
-		ReqID:  h.ID,
-		RCode:  h.RCode,
-		Expire: now.Add(time.Second * 600),
+		ReqID: h.ID,
+		RCode: h.RCode,
 	}
 
 L:
@@ -193,12 +315,8 @@ L:
 			break
 		}
 
-		ttl := ah.TTL
-		if ttl == 0 {
-			ttl = 600
-		}
-		expire := now.Add(time.Duration(ttl) * time.Second)
-		if ipRecord.Expire.After(expire) {
+		expire := now.Add(time.Duration(ah.TTL) * time.Second)
+		if ipRecord.Expire.IsZero() || ipRecord.Expire.After(expire) {
 			ipRecord.Expire = expire
 		}
 
@@ -226,5 +344,50 @@ L:
 		}
 	}
 
+	// Answers without records, such as NXDOMAIN, are cached for a fixed period.
+	if ipRecord.Expire.IsZero() {
+		ipRecord.Expire = now.Add(time.Duration(defaultTTL) * time.Second)
+	}
+
 	return ipRecord, nil
 }
+
+// subscribeRecords subscribes to records of the domain published by a name server. IPv4 and IPv6 belong to
+// different subscription groups.
+func subscribeRecords(pub *pubsub.Service, domain string, option IPOption) (sub4, sub6 *pubsub.Subscriber) {
+	if option.IPv4Enable {
+		sub4 = pub.Subscribe(domain + "4")
+	}
+	if option.IPv6Enable {
+		sub6 = pub.Subscribe(domain + "6")
+	}
+	return
+}
+
+// waitRecords waits for records published to the subscribers, and returns IPs in them along with their TTL. If
+// the context is done before all records arrive, IPs in the received ones are returned.
+func waitRecords(ctx context.Context, sub4, sub6 *pubsub.Subscriber, option IPOption) ([]net.IP, uint32, error) {
+	var rec record
+L:
+	for _, sub := range []*pubsub.Subscriber{sub4, sub6} {
+		if sub == nil {
+			continue
+		}
+		select {
+		case msg := <-sub.Wait():
+			if sub == sub4 {
+				rec.A = msg.(*IPRecord)
+			} else {
+				rec.AAAA = msg.(*IPRecord)
+			}
+		case <-ctx.Done():
+			break L
+		}
+	}
+
+	ips, ttl, err := rec.getIPs(option)
+	if err == errRecordNotFound && ctx.Err() != nil {
+		return nil, 0, ctx.Err()
+	}
+	return ips, ttl, err
+}
@@ -14,15 +14,11 @@ import (
 	"sync/atomic"
 	"time"
 
-	dns_feature "v2ray.com/core/features/dns"
-
 	"golang.org/x/net/dns/dnsmessage"
-	"v2ray.com/core/common"
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/protocol/dns"
 	"v2ray.com/core/common/session"
 	"v2ray.com/core/common/signal/pubsub"
-	"v2ray.com/core/common/task"
 	"v2ray.com/core/features/routing"
 	"v2ray.com/core/transport/internet"
 )
@@ -32,9 +28,8 @@ import (
 // thus most of the DOH implementation is copied from udpns.go
 type DoHNameServer struct {
 	sync.RWMutex
-	ips        map[string]record
+	cache      *Cache
 	pub        *pubsub.Service
-	cleanup    *task.Periodic
 	reqID      uint32
 	clientIP   net.IP
 	httpClient *http.Client
@@ -43,10 +38,10 @@ type DoHNameServer struct {
 }
 
 // NewDoHNameServer creates DOH client object for remote resolving
-func NewDoHNameServer(url *url.URL, dispatcher routing.Dispatcher, clientIP net.IP) (*DoHNameServer, error) {
+func NewDoHNameServer(url *url.URL, dispatcher routing.Dispatcher, clientIP net.IP, cache *Cache) (*DoHNameServer, error) {
 
 	newError("DNS: created Remote DOH client for ", url.String()).AtInfo().WriteToLog()
-	s := baseDOHNameServer(url, "DOH", clientIP)
+	s := baseDOHNameServer(url, "DOH", clientIP, cache)
 
 	// Dispatched connection will be closed (interrupted) after each request
 	// This makes DOH inefficient without a keep-alived connection
@@ -86,9 +81,9 @@ func NewDoHNameServer(url *url.URL, dispatcher routing.Dispatcher, clientIP net.
 }
 
 // NewDoHLocalNameServer creates DOH client object for local resolving
-func NewDoHLocalNameServer(url *url.URL, clientIP net.IP) *DoHNameServer {
+func NewDoHLocalNameServer(url *url.URL, clientIP net.IP, cache *Cache) *DoHNameServer {
 	url.Scheme = "https"
-	s := baseDOHNameServer(url, "DOHL", clientIP)
+	s := baseDOHNameServer(url, "DOHL", clientIP, cache)
 	tr := &http.Transport{
 		IdleConnTimeout:   90 * time.Second,
 		ForceAttemptHTTP2: true,
@@ -112,19 +107,15 @@ func NewDoHLocalNameServer(url *url.URL, clientIP net.IP) *DoHNameServer {
 	return s
 }
 
-func baseDOHNameServer(url *url.URL, prefix string, clientIP net.IP) *DoHNameServer {
+func baseDOHNameServer(url *url.URL, prefix string, clientIP net.IP, cache *Cache) *DoHNameServer {
 
 	s := &DoHNameServer{
-		ips:      make(map[string]record),
+		cache:    cache,
 		clientIP: clientIP,
 		pub:      pubsub.NewService(),
 		name:     prefix + "//" + url.Host,
 		dohURL:   url.String(),
 	}
-	s.cleanup = &task.Periodic{
-		Interval: time.Minute,
-		Execute:  s.Cleanup,
-	}
 
 	return s
 }
@@ -134,52 +125,13 @@ func (s *DoHNameServer) Name() string {
 	return s.name
 }
 
-// Cleanup clears expired items from cache
-func (s *DoHNameServer) Cleanup() error {
-	now := time.Now()
-	s.Lock()
-	defer s.Unlock()
-
-	if len(s.ips) == 0 {
-		return newError("nothing to do. stopping...")
-	}
-
-	for domain, record := range s.ips {
-		if record.A != nil && record.A.Expire.Before(now) {
-			record.A = nil
-		}
-		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
-			record.AAAA = nil
-		}
-
-		if record.A == nil && record.AAAA == nil {
-			newError(s.name, " cleanup ", domain).AtDebug().WriteToLog()
-			delete(s.ips, domain)
-		} else {
-			s.ips[domain] = record
-		}
-	}
-
-	if len(s.ips) == 0 {
-		s.ips = make(map[string]record)
-	}
-
-	return nil
-}
-
 func (s *DoHNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
 	elapsed := time.Since(req.start)
 
-	s.Lock()
-	rec := s.ips[req.domain]
-	updated := false
-
+	var rec record
 	switch req.reqType {
 	case dnsmessage.TypeA:
-		if isNewer(rec.A, ipRec) {
-			rec.A = ipRec
-			updated = true
-		}
+		rec.A = ipRec
 	case dnsmessage.TypeAAAA:
 		addr := make([]net.Address, 0)
 		for _, ip := range ipRec.IP {
@@ -188,24 +140,19 @@ func (s *DoHNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
 			}
 		}
 		ipRec.IP = addr
-		if isNewer(rec.AAAA, ipRec) {
-			rec.AAAA = ipRec
-			updated = true
-		}
+		rec.AAAA = ipRec
 	}
 	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
 
-	if updated {
-		s.ips[req.domain] = rec
+	if s.cache != nil {
+		s.cache.Update(s.name+" "+req.domain, rec)
 	}
 	switch req.reqType {
 	case dnsmessage.TypeA:
-		s.pub.Publish(req.domain+"4", nil)
+		s.pub.Publish(req.domain+"4", ipRec)
 	case dnsmessage.TypeAAAA:
-		s.pub.Publish(req.domain+"6", nil)
+		s.pub.Publish(req.domain+"6", ipRec)
 	}
-	s.Unlock()
-	common.Must(s.cleanup.Start())
 }
 
 func (s *DoHNameServer) newReqID() uint16 {
@@ -230,14 +177,8 @@ func (s *DoHNameServer) sendQuery(ctx context.Context, domain string, option IPO
 
 			// generate new context for each req, using same context
 			// may cause reqs all aborted if any one encounter an error
-			dnsCtx := context.Background()
-
 			// reserve internal dns server requested Inbound
-			if inbound := session.InboundFromContext(ctx); inbound != nil {
-				dnsCtx = session.ContextWithInbound(dnsCtx, inbound)
-			}
-
-			dnsCtx = session.ContextWithContent(dnsCtx, &session.Content{
+			dnsCtx := linkContext(ctx, &session.Content{
 				Protocol:      "https",
 				SkipRoutePick: true,
 			})
@@ -293,96 +234,59 @@ func (s *DoHNameServer) dohHTTPSContext(ctx context.Context, b []byte) ([]byte,
 	return ioutil.ReadAll(resp.Body)
 }
 
-func (s *DoHNameServer) findIPsForDomain(domain string, option IPOption) ([]net.IP, error) {
-	s.RLock()
-	record, found := s.ips[domain]
-	s.RUnlock()
-
-	if !found {
-		return nil, errRecordNotFound
-	}
+// QueryRecords implements recordQuerier.
+func (s *DoHNameServer) QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	newError(s.name, " querying ", qType, ": ", domain).AtInfo().WriteToLog(session.ExportIDToError(ctx))
 
-	var ips []net.Address
-	var lastErr error
-	if option.IPv6Enable && record.AAAA != nil && record.AAAA.RCode == dnsmessage.RCodeSuccess {
-		aaaa, err := record.AAAA.getIPs()
-		if err != nil {
-			lastErr = err
-		}
-		ips = append(ips, aaaa...)
-	}
-
-	if option.IPv4Enable && record.A != nil && record.A.RCode == dnsmessage.RCodeSuccess {
-		a, err := record.A.getIPs()
-		if err != nil {
-			lastErr = err
-		}
-		ips = append(ips, a...)
+	msg, err := buildRecordQuery(domain, qType, s.newReqID(), genEDNS0Options(s.clientIP))
+	if err != nil {
+		return nil, err
 	}
-
-	if len(ips) > 0 {
-		return toNetIP(ips), nil
+	b, err := dns.PackMessage(msg)
+	if err != nil {
+		return nil, newError("failed to pack dns query").Base(err)
 	}
+	defer b.Release()
 
-	if lastErr != nil {
-		return nil, lastErr
+	content := &session.Content{
+		Protocol:      "https",
+		SkipRoutePick: true,
 	}
-
-	if (option.IPv4Enable && record.A != nil) || (option.IPv6Enable && record.AAAA != nil) {
-		return nil, dns_feature.ErrEmptyResponse
+	content.SetAttribute(ownLinkAttribute, true)
+	dnsCtx := session.ContextWithContent(ctx, content)
+	dnsCtx = session.ContextWithMuxPrefered(dnsCtx, true)
+	resp, err := s.dohHTTPSContext(dnsCtx, b.Bytes())
+	if err != nil {
+		return nil, newError(s.name, " failed to retrieve response").Base(err)
 	}
 
-	return nil, errRecordNotFound
+	return parseRecords(resp)
 }
 
 // QueryIP is called from dns.Server->queryIPTimeout
-func (s *DoHNameServer) QueryIP(ctx context.Context, domain string, option IPOption) ([]net.IP, error) {
+func (s *DoHNameServer) QueryIP(ctx context.Context, domain string, option IPOption) ([]net.IP, uint32, error) {
 	fqdn := Fqdn(domain)
 
-	ips, err := s.findIPsForDomain(fqdn, option)
-	if err != errRecordNotFound {
-		newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
-		return ips, err
+	if s.cache != nil {
+		ips, ttl, refresh, err := s.cache.Lookup(s.name+" "+fqdn, option)
+		if err != errRecordNotFound {
+			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
+			if refresh {
+				newError(s.name, " refreshing stale record of ", domain).AtDebug().WriteToLog()
+				s.sendQuery(ctx, fqdn, option)
+			}
+			return ips, ttl, err
+		}
 	}
 
-	// ipv4 and ipv6 belong to different subscription groups
-	var sub4, sub6 *pubsub.Subscriber
-	if option.IPv4Enable {
-		sub4 = s.pub.Subscribe(fqdn + "4")
+	sub4, sub6 := subscribeRecords(s.pub, fqdn, option)
+	if sub4 != nil {
 		defer sub4.Close()
 	}
-	if option.IPv6Enable {
-		sub6 = s.pub.Subscribe(fqdn + "6")
+	if sub6 != nil {
 		defer sub6.Close()
 	}
-	done := make(chan interface{})
-	go func() {
-		if sub4 != nil {
-			select {
-			case <-sub4.Wait():
-			case <-ctx.Done():
-			}
-		}
-		if sub6 != nil {
-			select {
-			case <-sub6.Wait():
-			case <-ctx.Done():
-			}
-		}
-		close(done)
-	}()
 	s.sendQuery(ctx, fqdn, option)
 
-	for {
-		ips, err := s.findIPsForDomain(fqdn, option)
-		if err != errRecordNotFound {
-			return ips, err
-		}
-
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-done:
-		}
-	}
+	return waitRecords(ctx, sub4, sub6, option)
 }
@@ -0,0 +1,47 @@
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/features/dns"
+)
+
+// FakeDNSServer is a Client that answers queries with synthetic IPs from a dns.FakeDNSEngine.
+type FakeDNSServer struct {
+	fakeDNSEngine dns.FakeDNSEngine
+}
+
+// NewFakeDNSServer creates a FakeDNSServer with the given engine.
+func NewFakeDNSServer(engine dns.FakeDNSEngine) *FakeDNSServer {
+	newError("DNS: created fakedns client").AtInfo().WriteToLog()
+	return &FakeDNSServer{fakeDNSEngine: engine}
+}
+
+// Name implements Client.
+func (FakeDNSServer) Name() string {
+	return "FakeDNS"
+}
+
+// fakeDNSTTL is the TTL of fake IPs. It is short, as an IP is reassigned to another domain when the pool is full.
+const fakeDNSTTL uint32 = 1
+
+// QueryIP implements Client.
+func (f *FakeDNSServer) QueryIP(ctx context.Context, domain string, option IPOption) ([]net.IP, uint32, error) {
+	if f.fakeDNSEngine == nil {
+		return nil, 0, newError("FakeDNS is not configured")
+	}
+	var ips []net.IP
+	for _, ip := range f.fakeDNSEngine.GetFakeIPForDomain(domain) {
+		if (option.IPv4Enable && ip.Family().IsIPv4()) || (option.IPv6Enable && ip.Family().IsIPv6()) {
+			ips = append(ips, ip.IP())
+		}
+	}
+	if len(ips) == 0 {
+		return nil, 0, dns.ErrEmptyResponse
+	}
+	newError("FakeDNS got answer: ", domain, " -> ", ips).AtInfo().WriteToLog()
+	return ips, fakeDNSTTL, nil
+}
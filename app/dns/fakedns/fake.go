@@ -0,0 +1,232 @@
+// +build !confonly
+
+package fakedns
+
+//go:generate errorgen
+
+import (
+	"container/list"
+	"context"
+	"math"
+	"math/big"
+	gonet "net"
+	"sync"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/features/dns"
+)
+
+type entry struct {
+	domain string
+	offset uint64
+}
+
+// Holder is an implementation of dns.FakeDNSEngine with a single pool of IPs.
+type Holder struct {
+	access   sync.Mutex
+	ipRange  *gonet.IPNet
+	capacity uint64
+	// next is the offset of the next IP that is never assigned.
+	next uint64
+	// lru holds entries from the most recently used to the least recently used.
+	lru      *list.List
+	byDomain map[string]*list.Element
+	byOffset map[uint64]*list.Element
+}
+
+// NewHolder creates a Holder with the given config.
+func NewHolder(config *FakeDnsPool) (*Holder, error) {
+	_, ipRange, err := gonet.ParseCIDR(config.IpPool)
+	if err != nil {
+		return nil, newError("invalid IP pool ", config.IpPool).Base(err)
+	}
+	ones, bits := ipRange.Mask.Size()
+	// The network address itself is not handed out.
+	capacity := uint64(math.MaxInt32)
+	if hostBits := bits - ones; hostBits < 31 {
+		capacity = uint64(1)<<uint(hostBits) - 1
+	}
+	if capacity == 0 {
+		return nil, newError("IP pool ", config.IpPool, " is too small")
+	}
+	lruSize := uint64(65535)
+	if config.LruSize > 0 {
+		lruSize = uint64(config.LruSize)
+	}
+	if lruSize < capacity {
+		capacity = lruSize
+	}
+	return &Holder{
+		ipRange:  ipRange,
+		capacity: capacity,
+		lru:      list.New(),
+		byDomain: make(map[string]*list.Element),
+		byOffset: make(map[uint64]*list.Element),
+	}, nil
+}
+
+// Type implements common.HasType.
+func (*Holder) Type() interface{} {
+	return dns.FakeDNSEngineType()
+}
+
+// Start implements common.Runnable.
+func (*Holder) Start() error {
+	return nil
+}
+
+// Close implements common.Closable.
+func (*Holder) Close() error {
+	return nil
+}
+
+func (h *Holder) ipAt(offset uint64) net.Address {
+	ip := new(big.Int).SetBytes(h.ipRange.IP)
+	ip.Add(ip, new(big.Int).SetUint64(offset+1))
+	b := ip.Bytes()
+	raw := make([]byte, len(h.ipRange.IP))
+	copy(raw[len(raw)-len(b):], b)
+	return net.IPAddress(raw)
+}
+
+func (h *Holder) offsetOf(ip net.Address) (uint64, bool) {
+	if !ip.Family().IsIP() || !h.ipRange.Contains(ip.IP()) {
+		return 0, false
+	}
+	raw := ip.IP()
+	if len(h.ipRange.IP) == net.IPv4len {
+		raw = raw.To4()
+	} else {
+		raw = raw.To16()
+	}
+	diff := new(big.Int).Sub(new(big.Int).SetBytes(raw), new(big.Int).SetBytes(h.ipRange.IP))
+	if diff.Sign() <= 0 || !diff.IsUint64() {
+		return 0, false
+	}
+	return diff.Uint64() - 1, true
+}
+
+// GetFakeIPForDomain implements dns.FakeDNSEngine. When the pool is full, the IP of the least recently used
+// domain is recycled.
+func (h *Holder) GetFakeIPForDomain(domain string) []net.Address {
+	h.access.Lock()
+	defer h.access.Unlock()
+
+	if e, found := h.byDomain[domain]; found {
+		h.lru.MoveToFront(e)
+		return []net.Address{h.ipAt(e.Value.(*entry).offset)}
+	}
+
+	var offset uint64
+	if h.next < h.capacity {
+		offset = h.next
+		h.next++
+	} else {
+		e := h.lru.Back()
+		old := h.lru.Remove(e).(*entry)
+		delete(h.byDomain, old.domain)
+		delete(h.byOffset, old.offset)
+		offset = old.offset
+	}
+	e := h.lru.PushFront(&entry{domain: domain, offset: offset})
+	h.byDomain[domain] = e
+	h.byOffset[offset] = e
+	return []net.Address{h.ipAt(offset)}
+}
+
+// GetDomainFromFakeDNS implements dns.FakeDNSEngine.
+func (h *Holder) GetDomainFromFakeDNS(ip net.Address) string {
+	offset, ok := h.offsetOf(ip)
+	if !ok {
+		return ""
+	}
+
+	h.access.Lock()
+	defer h.access.Unlock()
+
+	if e, found := h.byOffset[offset]; found {
+		h.lru.MoveToFront(e)
+		return e.Value.(*entry).domain
+	}
+	return ""
+}
+
+// IsIPInIPPool implements dns.FakeDNSEngine.
+func (h *Holder) IsIPInIPPool(ip net.Address) bool {
+	return ip.Family().IsIP() && h.ipRange.Contains(ip.IP())
+}
+
+// HolderMulti is an implementation of dns.FakeDNSEngine with multiple pools, such as one for IPv4 and one for IPv6.
+type HolderMulti struct {
+	holders []*Holder
+}
+
+// NewHolderMulti creates a HolderMulti with the given config.
+func NewHolderMulti(config *FakeDnsPoolMulti) (*HolderMulti, error) {
+	if len(config.Pools) == 0 {
+		return nil, newError("no IP pool is specified")
+	}
+	m := new(HolderMulti)
+	for _, pool := range config.Pools {
+		h, err := NewHolder(pool)
+		if err != nil {
+			return nil, err
+		}
+		m.holders = append(m.holders, h)
+	}
+	return m, nil
+}
+
+// Type implements common.HasType.
+func (*HolderMulti) Type() interface{} {
+	return dns.FakeDNSEngineType()
+}
+
+// Start implements common.Runnable.
+func (*HolderMulti) Start() error {
+	return nil
+}
+
+// Close implements common.Closable.
+func (*HolderMulti) Close() error {
+	return nil
+}
+
+// GetFakeIPForDomain implements dns.FakeDNSEngine. It returns an IP from each pool.
+func (m *HolderMulti) GetFakeIPForDomain(domain string) []net.Address {
+	var ips []net.Address
+	for _, h := range m.holders {
+		ips = append(ips, h.GetFakeIPForDomain(domain)...)
+	}
+	return ips
+}
+
+// GetDomainFromFakeDNS implements dns.FakeDNSEngine.
+func (m *HolderMulti) GetDomainFromFakeDNS(ip net.Address) string {
+	for _, h := range m.holders {
+		if domain := h.GetDomainFromFakeDNS(ip); len(domain) > 0 {
+			return domain
+		}
+	}
+	return ""
+}
+
+// IsIPInIPPool implements dns.FakeDNSEngine.
+func (m *HolderMulti) IsIPInIPPool(ip net.Address) bool {
+	for _, h := range m.holders {
+		if h.IsIPInIPPool(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*FakeDnsPool)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return NewHolder(config.(*FakeDnsPool))
+	}))
+	common.Must(common.RegisterConfig((*FakeDnsPoolMulti)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return NewHolderMulti(config.(*FakeDnsPoolMulti))
+	}))
+}
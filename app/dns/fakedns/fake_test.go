@@ -0,0 +1,68 @@
+package fakedns_test
+
+import (
+	"testing"
+
+	. "v2ray.com/core/app/dns/fakedns"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+)
+
+func TestFakeDNS(t *testing.T) {
+	h, err := NewHolder(&FakeDnsPool{
+		IpPool:  "198.18.0.0/15",
+		LruSize: 2,
+	})
+	common.Must(err)
+
+	ip1 := h.GetFakeIPForDomain("www.v2ray.com")
+	if len(ip1) != 1 || ip1[0].String() != "198.18.0.1" {
+		t.Fatal("unexpected IP: ", ip1)
+	}
+	if ip := h.GetFakeIPForDomain("www.v2ray.com"); ip[0] != ip1[0] {
+		t.Error("expect the same IP for the same domain, but got ", ip)
+	}
+	ip2 := h.GetFakeIPForDomain("www.github.com")
+	if ip2[0].String() != "198.18.0.2" {
+		t.Fatal("unexpected IP: ", ip2)
+	}
+
+	if domain := h.GetDomainFromFakeDNS(ip1[0]); domain != "www.v2ray.com" {
+		t.Error("unexpected domain: ", domain)
+	}
+	if domain := h.GetDomainFromFakeDNS(net.ParseAddress("198.18.0.3")); domain != "" {
+		t.Error("expect no domain, but got ", domain)
+	}
+	if !h.IsIPInIPPool(net.ParseAddress("198.19.255.255")) || h.IsIPInIPPool(net.ParseAddress("8.8.8.8")) {
+		t.Error("unexpected result of IsIPInIPPool")
+	}
+
+	// www.github.com is the least recently used, so its IP is recycled.
+	ip3 := h.GetFakeIPForDomain("www.google.com")
+	if ip3[0] != ip2[0] {
+		t.Error("expect recycled IP ", ip2, ", but got ", ip3)
+	}
+	if domain := h.GetDomainFromFakeDNS(ip2[0]); domain != "www.google.com" {
+		t.Error("unexpected domain: ", domain)
+	}
+}
+
+func TestFakeDNSMulti(t *testing.T) {
+	h, err := NewHolderMulti(&FakeDnsPoolMulti{
+		Pools: []*FakeDnsPool{
+			{IpPool: "198.18.0.0/15"},
+			{IpPool: "fc00::/18"},
+		},
+	})
+	common.Must(err)
+
+	ips := h.GetFakeIPForDomain("www.v2ray.com")
+	if len(ips) != 2 || ips[0].String() != "198.18.0.1" || ips[1].IP().String() != "fc00::1" {
+		t.Fatal("unexpected IPs: ", ips)
+	}
+	for _, ip := range ips {
+		if domain := h.GetDomainFromFakeDNS(ip); domain != "www.v2ray.com" {
+			t.Error("unexpected domain of ", ip, ": ", domain)
+		}
+	}
+}
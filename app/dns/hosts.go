@@ -3,6 +3,8 @@
 package dns
 
 import (
+	"sync"
+
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/strmatcher"
@@ -11,8 +13,11 @@ import (
 
 // StaticHosts represents static domain-ip mapping in DNS server.
 type StaticHosts struct {
+	access   sync.RWMutex
 	ips      [][]net.Address
 	matchers *strmatcher.MatcherGroup
+	// domains maps IPs to the domains of full matching that have them, for reverse lookups.
+	domains map[string][]string
 }
 
 var typeMap = map[DomainMatchingType]strmatcher.Type{
@@ -40,7 +45,11 @@ func NewStaticHosts(hosts []*Config_HostMapping, legacy map[string]*net.IPOrDoma
 	sh := &StaticHosts{
 		ips:      make([][]net.Address, len(hosts)+len(legacy)+16),
 		matchers: g,
+		domains:  make(map[string][]string),
 	}
+	// fullIDs tracks the latest mapping of each full domain. A later mapping replaces the earlier one in matching, so
+	// it replaces the reverse entries as well.
+	fullIDs := make(map[string]uint32)
 
 	if legacy != nil {
 		features.PrintDeprecatedFeatureWarning("simple host mapping")
@@ -56,6 +65,8 @@ func NewStaticHosts(hosts []*Config_HostMapping, legacy map[string]*net.IPOrDoma
 			}
 
 			sh.ips[id] = []net.Address{address}
+			sh.addReverse(domain, address)
+			fullIDs[domain] = id
 		}
 	}
 
@@ -65,6 +76,12 @@ func NewStaticHosts(hosts []*Config_HostMapping, legacy map[string]*net.IPOrDoma
 			return nil, newError("failed to create domain matcher").Base(err)
 		}
 		id := g.Add(matcher)
+		if mapping.Type == DomainMatchingType_Full {
+			if prev, found := fullIDs[mapping.Domain]; found {
+				sh.removeReverse(mapping.Domain, sh.ips[prev])
+			}
+			fullIDs[mapping.Domain] = id
+		}
 		ips := make([]net.Address, 0, len(mapping.Ip)+1)
 		if len(mapping.Ip) > 0 {
 			for _, ip := range mapping.Ip {
@@ -73,6 +90,9 @@ func NewStaticHosts(hosts []*Config_HostMapping, legacy map[string]*net.IPOrDoma
 					return nil, newError("invalid IP address in static hosts: ", ip).AtWarning()
 				}
 				ips = append(ips, addr)
+				if mapping.Type == DomainMatchingType_Full {
+					sh.addReverse(mapping.Domain, addr)
+				}
 			}
 		} else if len(mapping.ProxiedDomain) > 0 {
 			ips = append(ips, net.DomainAddress(mapping.ProxiedDomain))
@@ -91,6 +111,42 @@ func NewStaticHosts(hosts []*Config_HostMapping, legacy map[string]*net.IPOrDoma
 	return sh, nil
 }
 
+func (h *StaticHosts) addReverse(domain string, ip net.Address) {
+	key := ip.IP().String()
+	h.domains[key] = append(h.domains[key], domain)
+}
+
+// removeReverse removes the domain from reverse entries of the given IPs.
+func (h *StaticHosts) removeReverse(domain string, ips []net.Address) {
+	for _, ip := range ips {
+		if ip.Family().IsDomain() {
+			continue
+		}
+		key := ip.IP().String()
+		domains := h.domains[key][:0]
+		for _, d := range h.domains[key] {
+			if d != domain {
+				domains = append(domains, d)
+			}
+		}
+		if len(domains) == 0 {
+			delete(h.domains, key)
+		} else {
+			h.domains[key] = domains
+		}
+	}
+}
+
+// update replaces all mappings with the ones of other, which is not used afterwards.
+func (h *StaticHosts) update(other *StaticHosts) {
+	h.access.Lock()
+	defer h.access.Unlock()
+
+	h.ips = other.ips
+	h.matchers = other.matchers
+	h.domains = other.domains
+}
+
 func filterIP(ips []net.Address, option IPOption) []net.Address {
 	filtered := make([]net.Address, 0, len(ips))
 	for _, ip := range ips {
@@ -106,6 +162,9 @@ func filterIP(ips []net.Address, option IPOption) []net.Address {
 
 // LookupIP returns IP address for the given domain, if exists in this StaticHosts.
 func (h *StaticHosts) LookupIP(domain string, option IPOption) []net.Address {
+	h.access.RLock()
+	defer h.access.RUnlock()
+
 	id := h.matchers.Match(domain)
 	if id == 0 {
 		return nil
@@ -116,3 +175,12 @@ func (h *StaticHosts) LookupIP(domain string, option IPOption) []net.Address {
 	}
 	return filterIP(ips, option)
 }
+
+// LookupDomains returns domains that have the given IP in this StaticHosts. Only domains of full matching are
+// considered.
+func (h *StaticHosts) LookupDomains(ip net.IP) []string {
+	h.access.RLock()
+	defer h.access.RUnlock()
+
+	return h.domains[ip.String()]
+}
@@ -0,0 +1,214 @@
+// +build !confonly
+
+package dns
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/task"
+)
+
+// parseHostsFile parses lines of /etc/hosts format into mappings of full domains. Lines with invalid IPs are skipped.
+func parseHostsFile(r io.Reader) ([]*Config_HostMapping, error) {
+	var mappings []*Config_HostMapping
+	index := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 2 {
+			continue
+		}
+
+		ip := net.ParseIP(fields[0])
+		if ip == nil {
+			newError("skipping invalid IP at line ", line, " of hosts file: ", fields[0]).AtWarning().WriteToLog()
+			continue
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+
+		for _, domain := range fields[1:] {
+			if i, found := index[domain]; found {
+				mappings[i].Ip = append(mappings[i].Ip, ip)
+				continue
+			}
+			index[domain] = len(mappings)
+			mappings = append(mappings, &Config_HostMapping{
+				Type:   DomainMatchingType_Full,
+				Domain: domain,
+				Ip:     [][]byte{ip},
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, newError("failed to read hosts file").Base(err)
+	}
+	return mappings, nil
+}
+
+var domainListPrefixes = []struct {
+	prefix string
+	t      DomainMatchingType
+}{
+	{"full:", DomainMatchingType_Full},
+	{"domain:", DomainMatchingType_Subdomain},
+	{"keyword:", DomainMatchingType_Keyword},
+	{"regexp:", DomainMatchingType_Regex},
+}
+
+// parseDomainList parses lines of domains into mappings to the IPs or proxied domain of the file.
+func parseDomainList(r io.Reader, file *HostsFile) ([]*Config_HostMapping, error) {
+	var mappings []*Config_HostMapping
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if len(text) == 0 {
+			continue
+		}
+
+		mapping := &Config_HostMapping{
+			Type:          DomainMatchingType_Subdomain,
+			Domain:        text,
+			Ip:            file.Ip,
+			ProxiedDomain: file.ProxiedDomain,
+		}
+		for _, p := range domainListPrefixes {
+			if strings.HasPrefix(text, p.prefix) {
+				mapping.Type = p.t
+				mapping.Domain = text[len(p.prefix):]
+				break
+			}
+		}
+		mappings = append(mappings, mapping)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, newError("failed to read domain list").Base(err)
+	}
+	return mappings, nil
+}
+
+func loadHostsFile(file *HostsFile) ([]*Config_HostMapping, error) {
+	f, err := os.Open(file.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch file.Format {
+	case HostsFile_HOSTS:
+		return parseHostsFile(f)
+	case HostsFile_DOMAIN_LIST:
+		if len(file.Ip) == 0 && len(file.ProxiedDomain) == 0 {
+			return nil, newError("neither IP address nor proxied domain specified for domain list")
+		}
+		return parseDomainList(f, file)
+	default:
+		return nil, newError("unknown format of hosts file: ", file.Format)
+	}
+}
+
+type hostsFileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// hostsFileWatcher builds StaticHosts from the config and hosts files, and updates it when any of the files changes.
+type hostsFileWatcher struct {
+	config  *Config
+	hosts   *StaticHosts
+	states  []hostsFileState
+	checker *task.Periodic
+}
+
+func newHostsFileWatcher(config *Config) (*hostsFileWatcher, error) {
+	w := &hostsFileWatcher{
+		config: config,
+	}
+	hosts, states, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	w.hosts = hosts
+	w.states = states
+
+	interval := time.Second * time.Duration(config.HostsFileCheckInterval)
+	if interval == 0 {
+		interval = time.Second * 10
+	}
+	w.checker = &task.Periodic{
+		Interval: interval,
+		Execute:  w.check,
+	}
+	return w, nil
+}
+
+func (w *hostsFileWatcher) load() (*StaticHosts, []hostsFileState, error) {
+	var mappings []*Config_HostMapping
+	states := make([]hostsFileState, len(w.config.HostsFile))
+	for i, file := range w.config.HostsFile {
+		info, err := os.Stat(file.Path)
+		if err != nil {
+			return nil, nil, newError("failed to load hosts file: ", file.Path).Base(err)
+		}
+		states[i] = hostsFileState{modTime: info.ModTime(), size: info.Size()}
+
+		m, err := loadHostsFile(file)
+		if err != nil {
+			return nil, nil, newError("failed to load hosts file: ", file.Path).Base(err)
+		}
+		mappings = append(mappings, m...)
+	}
+
+	// Static hosts in the config are added last, so that they replace the same domains in files.
+	hosts, err := NewStaticHosts(append(mappings, w.config.StaticHosts...), w.config.Hosts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return hosts, states, nil
+}
+
+func (w *hostsFileWatcher) changed() bool {
+	for i, file := range w.config.HostsFile {
+		info, err := os.Stat(file.Path)
+		if err != nil {
+			newError("failed to check hosts file: ", file.Path).Base(err).AtWarning().WriteToLog()
+			return false
+		}
+		if !info.ModTime().Equal(w.states[i].modTime) || info.Size() != w.states[i].size {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *hostsFileWatcher) check() error {
+	if !w.changed() {
+		return nil
+	}
+
+	hosts, states, err := w.load()
+	if err != nil {
+		newError("failed to reload hosts files, keeping the previous ones").Base(err).AtWarning().WriteToLog()
+		return nil
+	}
+	w.hosts.update(hosts)
+	w.states = states
+	newError("hosts files reloaded").AtInfo().WriteToLog()
+	return nil
+}
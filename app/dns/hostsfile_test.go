@@ -0,0 +1,126 @@
+// +build !confonly
+
+package dns
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+)
+
+func TestParseHostsFile(t *testing.T) {
+	mappings, err := parseHostsFile(strings.NewReader(`
+# comment
+127.0.0.1	localhost
+::1		localhost ip6-localhost # trailing comment
+10.0.0.1 a.v2ray.com b.v2ray.com
+fe80::1%lo0 link.local
+invalid
+`))
+	common.Must(err)
+
+	expected := []*Config_HostMapping{
+		{Type: DomainMatchingType_Full, Domain: "localhost", Ip: [][]byte{{127, 0, 0, 1}, []byte(net.LocalHostIPv6.IP())}},
+		{Type: DomainMatchingType_Full, Domain: "ip6-localhost", Ip: [][]byte{[]byte(net.LocalHostIPv6.IP())}},
+		{Type: DomainMatchingType_Full, Domain: "a.v2ray.com", Ip: [][]byte{{10, 0, 0, 1}}},
+		{Type: DomainMatchingType_Full, Domain: "b.v2ray.com", Ip: [][]byte{{10, 0, 0, 1}}},
+	}
+	if r := cmp.Diff(mappings, expected, cmp.Comparer(func(a, b *Config_HostMapping) bool {
+		return a.String() == b.String()
+	})); r != "" {
+		t.Error(r)
+	}
+}
+
+func TestParseDomainList(t *testing.T) {
+	file := &HostsFile{
+		Format: HostsFile_DOMAIN_LIST,
+		Ip:     [][]byte{{0, 0, 0, 0}},
+	}
+	mappings, err := parseDomainList(strings.NewReader(`
+# ads
+ads.v2ray.com
+full:tracker.v2ray.com
+keyword:doubleclick
+regexp:^ad[0-9]+\.
+`), file)
+	common.Must(err)
+
+	expected := []*Config_HostMapping{
+		{Type: DomainMatchingType_Subdomain, Domain: "ads.v2ray.com", Ip: file.Ip},
+		{Type: DomainMatchingType_Full, Domain: "tracker.v2ray.com", Ip: file.Ip},
+		{Type: DomainMatchingType_Keyword, Domain: "doubleclick", Ip: file.Ip},
+		{Type: DomainMatchingType_Regex, Domain: `^ad[0-9]+\.`, Ip: file.Ip},
+	}
+	if r := cmp.Diff(mappings, expected, cmp.Comparer(func(a, b *Config_HostMapping) bool {
+		return a.String() == b.String()
+	})); r != "" {
+		t.Error(r)
+	}
+}
+
+func TestHostsFileWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "v2ray-hosts")
+	common.Must(err)
+	defer os.RemoveAll(dir)
+
+	hostsPath := filepath.Join(dir, "hosts")
+	listPath := filepath.Join(dir, "ads.txt")
+	common.Must(ioutil.WriteFile(hostsPath, []byte("10.0.0.1 a.v2ray.com b.v2ray.com\n"), 0600))
+	common.Must(ioutil.WriteFile(listPath, []byte("ads.v2ray.com\n"), 0600))
+
+	w, err := newHostsFileWatcher(&Config{
+		StaticHosts: []*Config_HostMapping{
+			{Type: DomainMatchingType_Full, Domain: "b.v2ray.com", Ip: [][]byte{{10, 0, 0, 2}}},
+		},
+		HostsFile: []*HostsFile{
+			{Path: hostsPath},
+			{Path: listPath, Format: HostsFile_DOMAIN_LIST, Ip: [][]byte{{0, 0, 0, 0}}},
+		},
+	})
+	common.Must(err)
+
+	lookup := func(domain string) []net.Address {
+		return w.hosts.LookupIP(domain, IPOption{IPv4Enable: true, IPv6Enable: true})
+	}
+
+	if r := cmp.Diff(lookup("a.v2ray.com"), []net.Address{net.ParseAddress("10.0.0.1")}); r != "" {
+		t.Error(r)
+	}
+	// Static hosts in the config take precedence.
+	if r := cmp.Diff(lookup("b.v2ray.com"), []net.Address{net.ParseAddress("10.0.0.2")}); r != "" {
+		t.Error(r)
+	}
+	if r := cmp.Diff(w.hosts.LookupDomains(net.ParseIP("10.0.0.1")), []string{"a.v2ray.com"}); r != "" {
+		t.Error(r)
+	}
+	if r := cmp.Diff(w.hosts.LookupDomains(net.ParseIP("10.0.0.2")), []string{"b.v2ray.com"}); r != "" {
+		t.Error(r)
+	}
+	if r := cmp.Diff(lookup("www.ads.v2ray.com"), []net.Address{net.AnyIP}); r != "" {
+		t.Error(r)
+	}
+
+	common.Must(ioutil.WriteFile(hostsPath, []byte("10.0.0.3 a.v2ray.com c.v2ray.com\n"), 0600))
+	common.Must(w.check())
+
+	if r := cmp.Diff(lookup("a.v2ray.com"), []net.Address{net.ParseAddress("10.0.0.3")}); r != "" {
+		t.Error(r)
+	}
+	if r := cmp.Diff(lookup("c.v2ray.com"), []net.Address{net.ParseAddress("10.0.0.3")}); r != "" {
+		t.Error(r)
+	}
+
+	// A file that fails to load keeps the previous hosts.
+	common.Must(os.Remove(listPath))
+	common.Must(w.check())
+	if r := cmp.Diff(lookup("www.ads.v2ray.com"), []net.Address{net.AnyIP}); r != "" {
+		t.Error(r)
+	}
+}
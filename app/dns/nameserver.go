@@ -5,7 +5,9 @@ package dns
 import (
 	"context"
 
+	"golang.org/x/net/dns/dnsmessage"
 	"v2ray.com/core/common/net"
+	dns_feature "v2ray.com/core/features/dns"
 	"v2ray.com/core/features/dns/localdns"
 )
 
@@ -15,33 +17,196 @@ type IPOption struct {
 	IPv6Enable bool
 }
 
+// restrict returns the option with IP families disabled by the strategy.
+func (o IPOption) restrict(strategy QueryStrategy) IPOption {
+	switch strategy {
+	case QueryStrategy_USE_IP4:
+		o.IPv6Enable = false
+	case QueryStrategy_USE_IP6:
+		o.IPv4Enable = false
+	}
+	return o
+}
+
+func (o IPOption) isEmpty() bool {
+	return !o.IPv4Enable && !o.IPv6Enable
+}
+
+func (s QueryStrategy) isPreference() bool {
+	return s == QueryStrategy_PREFER_IP4 || s == QueryStrategy_PREFER_IP6
+}
+
+// sortIPs orders IPs of the preferred family first, keeping the order within each family.
+func sortIPs(ips []net.IP, strategy QueryStrategy) []net.IP {
+	if !strategy.isPreference() || len(ips) < 2 {
+		return ips
+	}
+	preferIPv4 := strategy == QueryStrategy_PREFER_IP4
+	sorted := make([]net.IP, 0, len(ips))
+	for _, ip := range ips {
+		if (ip.To4() != nil) == preferIPv4 {
+			sorted = append(sorted, ip)
+		}
+	}
+	for _, ip := range ips {
+		if (ip.To4() != nil) != preferIPv4 {
+			sorted = append(sorted, ip)
+		}
+	}
+	return sorted
+}
+
 // Client is the interface for DNS client.
 type Client interface {
 	// Name of the Client.
 	Name() string
 
-	// QueryIP sends IP queries to its configured server.
-	QueryIP(ctx context.Context, domain string, option IPOption) ([]net.IP, error)
+	// QueryIP sends IP queries to its configured server. It returns IPs along with their remaining TTL in seconds.
+	QueryIP(ctx context.Context, domain string, option IPOption) ([]net.IP, uint32, error)
+}
+
+// recordQuerier is a Client that can query records of types other than A and AAAA.
+type recordQuerier interface {
+	// QueryRecords sends a query of the given type to its configured server, and returns the answers.
+	QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error)
 }
 
 type localNameServer struct {
 	client *localdns.Client
 }
 
-func (s *localNameServer) QueryIP(ctx context.Context, domain string, option IPOption) ([]net.IP, error) {
-	if option.IPv4Enable && option.IPv6Enable {
-		return s.client.LookupIP(domain)
+// QueryIP implements Client. As the system resolver doesn't expose TTLs, answers have the default TTL.
+func (s *localNameServer) QueryIP(ctx context.Context, domain string, option IPOption) ([]net.IP, uint32, error) {
+	var ips []net.IP
+	var err error
+	switch {
+	case option.IPv4Enable && option.IPv6Enable:
+		ips, err = s.client.LookupIP(domain)
+	case option.IPv4Enable:
+		ips, err = s.client.LookupIPv4(domain)
+	case option.IPv6Enable:
+		ips, err = s.client.LookupIPv6(domain)
+	default:
+		return nil, 0, newError("neither IPv4 nor IPv6 is enabled")
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return ips, defaultTTL, nil
+}
+
+// QueryRecords implements recordQuerier. Only types that the system resolver can look up are supported, and
+// answers have the default TTL.
+func (s *localNameServer) QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	name, err := dnsmessage.NewName(Fqdn(domain))
+	if err != nil {
+		return nil, newError("invalid domain name: ", domain).Base(err)
+	}
+	header := dnsmessage.ResourceHeader{
+		Name:  name,
+		Class: dnsmessage.ClassINET,
+		TTL:   defaultTTL,
+	}
+
+	var bodies []dnsmessage.ResourceBody
+	resolver := &net.Resolver{}
+	switch qType {
+	case dnsmessage.TypeCNAME:
+		cname, err := resolver.LookupCNAME(ctx, domain)
+		if err != nil {
+			return nil, toRCodeError(err)
+		}
+		// The domain itself is returned if it has no CNAME record.
+		if cname != Fqdn(domain) {
+			target, err := dnsmessage.NewName(cname)
+			if err != nil {
+				return nil, err
+			}
+			bodies = append(bodies, &dnsmessage.CNAMEResource{CNAME: target})
+		}
+	case dnsmessage.TypeMX:
+		mxs, err := resolver.LookupMX(ctx, domain)
+		if err != nil {
+			return nil, toRCodeError(err)
+		}
+		for _, mx := range mxs {
+			host, err := dnsmessage.NewName(Fqdn(mx.Host))
+			if err != nil {
+				return nil, err
+			}
+			bodies = append(bodies, &dnsmessage.MXResource{Pref: mx.Pref, MX: host})
+		}
+	case dnsmessage.TypeTXT:
+		txts, err := resolver.LookupTXT(ctx, domain)
+		if err != nil {
+			return nil, toRCodeError(err)
+		}
+		for _, txt := range txts {
+			bodies = append(bodies, &dnsmessage.TXTResource{TXT: splitTXT(txt)})
+		}
+	case dnsmessage.TypeSRV:
+		_, srvs, err := resolver.LookupSRV(ctx, "", "", domain)
+		if err != nil {
+			return nil, toRCodeError(err)
+		}
+		for _, srv := range srvs {
+			target, err := dnsmessage.NewName(Fqdn(srv.Target))
+			if err != nil {
+				return nil, err
+			}
+			bodies = append(bodies, &dnsmessage.SRVResource{
+				Priority: srv.Priority,
+				Weight:   srv.Weight,
+				Port:     srv.Port,
+				Target:   target,
+			})
+		}
+	case dnsmessage.TypePTR:
+		ip := parsePTRName(domain)
+		if ip == nil {
+			return nil, newError("invalid name for PTR query: ", domain)
+		}
+		hosts, err := resolver.LookupAddr(ctx, ip.String())
+		if err != nil {
+			return nil, toRCodeError(err)
+		}
+		for _, host := range hosts {
+			ptr, err := dnsmessage.NewName(Fqdn(host))
+			if err != nil {
+				return nil, err
+			}
+			bodies = append(bodies, &dnsmessage.PTRResource{PTR: ptr})
+		}
+	default:
+		return nil, errRecordTypeNotSupported
 	}
 
-	if option.IPv4Enable {
-		return s.client.LookupIPv4(domain)
+	if len(bodies) == 0 {
+		return nil, dns_feature.ErrEmptyResponse
+	}
+	answers := make([]dnsmessage.Resource, 0, len(bodies))
+	for _, body := range bodies {
+		answers = append(answers, dnsmessage.Resource{Header: header, Body: body})
 	}
+	return answers, nil
+}
 
-	if option.IPv6Enable {
-		return s.client.LookupIPv6(domain)
+// toRCodeError converts the error of a domain that doesn't exist to NXDOMAIN.
+func toRCodeError(err error) error {
+	if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+		return dns_feature.RCodeError(dnsmessage.RCodeNameError)
 	}
+	return err
+}
 
-	return nil, newError("neither IPv4 nor IPv6 is enabled")
+// splitTXT splits a text into character strings of at most 255 bytes, as in a TXT record.
+func splitTXT(txt string) []string {
+	var segments []string
+	for len(txt) > 255 {
+		segments = append(segments, txt[:255])
+		txt = txt[255:]
+	}
+	return append(segments, txt)
 }
 
 func (s *localNameServer) Name() string {
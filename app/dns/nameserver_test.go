@@ -12,7 +12,7 @@ import (
 func TestLocalNameServer(t *testing.T) {
 	s := NewLocalNameServer()
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
-	ips, err := s.QueryIP(ctx, "google.com", IPOption{
+	ips, _, err := s.QueryIP(ctx, "google.com", IPOption{
 		IPv4Enable: true,
 		IPv6Enable: true,
 	})
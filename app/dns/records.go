@@ -0,0 +1,236 @@
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"v2ray.com/core/common/errors"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/features/dns"
+)
+
+var errRecordTypeNotSupported = errors.New("record type not supported")
+
+// parsePTRName returns the IP of a reverse lookup name, such as "4.3.2.1.in-addr.arpa.", or nil if it is not one.
+func parsePTRName(name string) net.IP {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	switch {
+	case strings.HasSuffix(name, ".in-addr.arpa"):
+		labels := strings.Split(strings.TrimSuffix(name, ".in-addr.arpa"), ".")
+		if len(labels) != net.IPv4len {
+			return nil
+		}
+		ip := make(net.IP, net.IPv4len)
+		for i, label := range labels {
+			v, err := strconv.ParseUint(label, 10, 8)
+			if err != nil {
+				return nil
+			}
+			ip[net.IPv4len-1-i] = byte(v)
+		}
+		return ip
+	case strings.HasSuffix(name, ".ip6.arpa"):
+		labels := strings.Split(strings.TrimSuffix(name, ".ip6.arpa"), ".")
+		if len(labels) != net.IPv6len*2 {
+			return nil
+		}
+		ip := make(net.IP, net.IPv6len)
+		for i, label := range labels {
+			v, err := strconv.ParseUint(label, 16, 4)
+			if err != nil || len(label) != 1 {
+				return nil
+			}
+			ip[net.IPv6len-1-i/2] |= byte(v) << (4 * uint(i%2))
+		}
+		return ip
+	default:
+		return nil
+	}
+}
+
+func ipResources(name dnsmessage.Name, ips []net.IP, ttl uint32) []dnsmessage.Resource {
+	header := dnsmessage.ResourceHeader{
+		Name:  name,
+		Class: dnsmessage.ClassINET,
+		TTL:   ttl,
+	}
+	answers := make([]dnsmessage.Resource, 0, len(ips))
+	for _, ip := range ips {
+		if ip4 := ip.To4(); ip4 != nil {
+			var r dnsmessage.AResource
+			copy(r.A[:], ip4)
+			answers = append(answers, dnsmessage.Resource{Header: header, Body: &r})
+		} else {
+			var r dnsmessage.AAAAResource
+			copy(r.AAAA[:], ip)
+			answers = append(answers, dnsmessage.Resource{Header: header, Body: &r})
+		}
+	}
+	return answers
+}
+
+// LookupRecords implements dns.RecordLookup. A and AAAA queries are resolved as in LookupIPv4 and LookupIPv6.
+// Queries of other types are answered by static hosts if the domain is there, or sent to name servers that support
+// them otherwise.
+func (s *Server) LookupRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	return s.lookupRecords(ctx, domain, qType, 0)
+}
+
+func (s *Server) lookupRecords(ctx context.Context, domain string, qType dnsmessage.Type, depth int32) ([]dnsmessage.Resource, error) {
+	if domain == "" {
+		return nil, newError("empty domain name")
+	}
+	name, err := dnsmessage.NewName(Fqdn(domain))
+	if err != nil {
+		return nil, newError("invalid domain name: ", domain).Base(err)
+	}
+	domain = strings.TrimSuffix(domain, ".")
+	header := dnsmessage.ResourceHeader{
+		Name:  name,
+		Class: dnsmessage.ClassINET,
+		TTL:   defaultTTL,
+	}
+
+	switch qType {
+	case dnsmessage.TypeA, dnsmessage.TypeAAAA:
+		ips, ttl, err := s.lookupIPInternal(ctx, domain, IPOption{
+			IPv4Enable: qType == dnsmessage.TypeA,
+			IPv6Enable: qType == dnsmessage.TypeAAAA,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return ipResources(name, ips, ttl), nil
+	case dnsmessage.TypePTR:
+		if ip := parsePTRName(domain); ip != nil {
+			if hosts := s.hosts.LookupDomains(ip); len(hosts) > 0 {
+				answers := make([]dnsmessage.Resource, 0, len(hosts))
+				for _, host := range hosts {
+					ptr, err := dnsmessage.NewName(Fqdn(host))
+					if err != nil {
+						return nil, newError("invalid domain in static hosts: ", host).Base(err)
+					}
+					answers = append(answers, dnsmessage.Resource{Header: header, Body: &dnsmessage.PTRResource{PTR: ptr}})
+				}
+				return answers, nil
+			}
+		}
+	default:
+		if addrs := s.hosts.LookupIP(domain, IPOption{IPv4Enable: true, IPv6Enable: true}); addrs != nil {
+			// A domain with IPs in static hosts has no records of other types.
+			if !addrs[0].Family().IsDomain() {
+				return nil, dns.ErrEmptyResponse
+			}
+			target, err := dnsmessage.NewName(Fqdn(addrs[0].Domain()))
+			if err != nil {
+				return nil, newError("invalid domain in static hosts: ", addrs[0].Domain()).Base(err)
+			}
+			answers := []dnsmessage.Resource{{Header: header, Body: &dnsmessage.CNAMEResource{CNAME: target}}}
+			if qType == dnsmessage.TypeCNAME || depth >= 5 {
+				return answers, nil
+			}
+			targetAnswers, err := s.lookupRecords(ctx, addrs[0].Domain(), qType, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			return append(answers, targetAnswers...), nil
+		}
+	}
+
+	return s.queryRecords(ctx, domain, qType)
+}
+
+func (s *Server) queryRecordsTimeout(ctx context.Context, client Client, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	querier, ok := client.(recordQuerier)
+	if !ok {
+		return nil, errRecordTypeNotSupported
+	}
+
+	queryCtx, cancel := s.queryContext(ctx)
+	defer cancel()
+	return querier.QueryRecords(queryCtx, domain, qType)
+}
+
+// queryRecords sends the query to name servers as rules decide, in the same order as IP queries.
+func (s *Server) queryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	if rule := s.matchRule(ctx, domain, qType); rule != nil {
+		switch rule.action {
+		case QueryAction_NXDOMAIN:
+			newError("blocking ", qType, " query of domain ", domain, " with NXDOMAIN").AtInfo().WriteToLog()
+			return nil, dns.RCodeError(dnsmessage.RCodeNameError)
+		case QueryAction_ZERO_IP:
+			newError("blocking ", qType, " query of domain ", domain, " with no records").AtInfo().WriteToLog()
+			return nil, dns.ErrEmptyResponse
+		}
+		if len(rule.servers) > 0 {
+			return s.queryRecordsAt(ctx, rule.servers, domain, qType)
+		}
+	}
+
+	var lastErr error
+	var matchedClient Client
+	if s.domainMatcher != nil {
+		idx := s.domainMatcher.Match(domain)
+		if idx > 0 {
+			matchedClient = s.clients[s.domainIndexMap[idx]]
+			answers, err := s.queryRecordsTimeout(ctx, matchedClient, domain, qType)
+			if len(answers) > 0 {
+				return answers, nil
+			}
+			if err == dns.ErrEmptyResponse {
+				return nil, err
+			}
+			if err != nil {
+				newError("failed to lookup ", qType, " for domain ", domain, " at server ", matchedClient.Name()).Base(err).WriteToLog()
+				lastErr = err
+			}
+		}
+	}
+
+	for _, client := range s.clients {
+		if client == matchedClient {
+			continue
+		}
+
+		answers, err := s.queryRecordsTimeout(ctx, client, domain, qType)
+		if len(answers) > 0 {
+			return answers, nil
+		}
+
+		if err != nil {
+			newError("failed to lookup ", qType, " for domain ", domain, " at server ", client.Name()).Base(err).WriteToLog()
+			lastErr = err
+		}
+		if err != context.Canceled && err != context.DeadlineExceeded && err != errRecordTypeNotSupported {
+			return nil, err
+		}
+	}
+
+	return nil, newError("returning nil for domain ", domain).Base(lastErr)
+}
+
+// queryRecordsAt sends the query to the given name servers in order.
+func (s *Server) queryRecordsAt(ctx context.Context, servers []uint32, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	var lastErr error
+	for _, idx := range servers {
+		client := s.clients[idx]
+		answers, err := s.queryRecordsTimeout(ctx, client, domain, qType)
+		if len(answers) > 0 {
+			return answers, nil
+		}
+
+		if err != nil {
+			newError("failed to lookup ", qType, " for domain ", domain, " at server ", client.Name()).Base(err).WriteToLog()
+			lastErr = err
+		}
+		if err != context.Canceled && err != context.DeadlineExceeded && err != errRecordTypeNotSupported {
+			return nil, err
+		}
+	}
+
+	return nil, newError("returning nil for domain ", domain).Base(lastErr)
+}
@@ -0,0 +1,29 @@
+// +build !confonly
+
+package dns
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"v2ray.com/core/common/net"
+)
+
+func TestParsePTRName(t *testing.T) {
+	cases := []struct {
+		name string
+		ip   net.IP
+	}{
+		{"4.3.2.1.in-addr.arpa.", net.IP{1, 2, 3, 4}},
+		{"4.3.2.1.IN-ADDR.ARPA", net.IP{1, 2, 3, 4}},
+		{"b.a.9.8.7.6.5.0.4.0.0.0.3.0.0.0.2.0.0.0.1.0.0.0.0.0.0.0.1.2.3.4.ip6.arpa.", net.ParseIP("4321:0:1:2:3:4:567:89ab")},
+		{"3.2.1.in-addr.arpa.", nil},
+		{"256.3.2.1.in-addr.arpa.", nil},
+		{"v2ray.com.", nil},
+	}
+	for _, c := range cases {
+		if r := cmp.Diff(parsePTRName(c.name), c.ip); r != "" {
+			t.Error(c.name, ": ", r)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"v2ray.com/core/app/router"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/features/routing"
+	routing_session "v2ray.com/core/features/routing/session"
+)
+
+// queryRoutingContext is the routing.Context of a DNS query. The inbound of the query is the one of the client.
+type queryRoutingContext struct {
+	routing_session.Context
+	domain string
+	qType  dnsmessage.Type
+}
+
+// GetTargetDomain implements routing.Context.
+func (ctx *queryRoutingContext) GetTargetDomain() string {
+	return ctx.domain
+}
+
+// QueryTypeMatcher matches the type of DNS queries.
+type QueryTypeMatcher struct {
+	types []dnsmessage.Type
+}
+
+func NewQueryTypeMatcher(types []uint32) *QueryTypeMatcher {
+	matcher := &QueryTypeMatcher{
+		types: make([]dnsmessage.Type, 0, len(types)),
+	}
+	for _, t := range types {
+		matcher.types = append(matcher.types, dnsmessage.Type(t))
+	}
+	return matcher
+}
+
+// Apply implements router.Condition. It never matches contexts other than DNS queries.
+func (m *QueryTypeMatcher) Apply(ctx routing.Context) bool {
+	query, ok := ctx.(*queryRoutingContext)
+	if !ok {
+		return false
+	}
+	for _, t := range m.types {
+		if t == query.qType {
+			return true
+		}
+	}
+	return false
+}
+
+// queryRule is a QueryRule with its conditions and name servers resolved.
+type queryRule struct {
+	condition router.Condition
+	action    QueryAction
+	// Indices of name servers that queries are sent to. Empty for the default way.
+	servers []uint32
+}
+
+func newQueryRule(rule *QueryRule, serverIndices map[string][]uint32) (*queryRule, error) {
+	conds := router.NewConditionChan()
+
+	if len(rule.Domain) > 0 {
+		matcher, err := router.NewDomainMatcher(rule.Domain)
+		if err != nil {
+			return nil, newError("failed to build domain condition").Base(err)
+		}
+		conds.Add(matcher)
+	}
+
+	if len(rule.QueryType) > 0 {
+		conds.Add(NewQueryTypeMatcher(rule.QueryType))
+	}
+
+	if len(rule.SourceGeoip) > 0 {
+		matcher, err := router.NewMultiGeoIPMatcher(rule.SourceGeoip, true)
+		if err != nil {
+			return nil, newError("failed to build source IP condition").Base(err)
+		}
+		conds.Add(matcher)
+	}
+
+	if len(rule.InboundTag) > 0 {
+		conds.Add(router.NewInboundTagMatcher(rule.InboundTag))
+	}
+
+	if conds.Len() == 0 {
+		return nil, newError("this rule has no effective fields").AtWarning()
+	}
+
+	r := &queryRule{
+		condition: conds,
+		action:    rule.Action,
+	}
+	if rule.Action == QueryAction_ROUTE && len(rule.ServerTag) > 0 {
+		servers, found := serverIndices[rule.ServerTag]
+		if !found {
+			return nil, newError("no name server of tag: ", rule.ServerTag)
+		}
+		r.servers = servers
+	}
+	return r, nil
+}
+
+// matchRule returns the first rule that matches the query, or nil if there is none.
+func (s *Server) matchRule(ctx context.Context, domain string, qType dnsmessage.Type) *queryRule {
+	if len(s.rules) == 0 {
+		return nil
+	}
+	query := &queryRoutingContext{
+		domain: domain,
+		qType:  qType,
+	}
+	query.Inbound = session.InboundFromContext(ctx)
+	for _, rule := range s.rules {
+		if rule.condition.Apply(query) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// blockedIPs returns the answer of a query blocked by a rule of QueryAction_ZERO_IP.
+func blockedIPs(option IPOption) []net.IP {
+	var ips []net.IP
+	if option.IPv4Enable {
+		ips = append(ips, make(net.IP, net.IPv4len))
+	}
+	if option.IPv6Enable {
+		ips = append(ips, make(net.IP, net.IPv6len))
+	}
+	return ips
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"golang.org/x/net/dns/dnsmessage"
 	"v2ray.com/core"
 	"v2ray.com/core/app/router"
 	"v2ray.com/core/common"
@@ -23,18 +24,25 @@ import (
 	"v2ray.com/core/features"
 	"v2ray.com/core/features/dns"
 	"v2ray.com/core/features/routing"
+	"v2ray.com/core/features/stats"
 )
 
 // Server is a DNS rely server.
 type Server struct {
 	sync.Mutex
 	hosts          *StaticHosts
+	hostsWatcher   *hostsFileWatcher
 	clients        []Client
+	cache          *Cache
 	clientIP       net.IP
 	domainMatcher  strmatcher.IndexMatcher
 	domainIndexMap map[uint32]uint32
 	ipIndexMap     map[uint32]*MultiGeoIPMatcher
-	tag            string
+	// strategyIndexMap holds query strategies of name servers that have one.
+	strategyIndexMap map[uint32]QueryStrategy
+	queryStrategy    QueryStrategy
+	rules            []*queryRule
+	tag              string
 }
 
 // MultiGeoIPMatcher for match
@@ -44,6 +52,9 @@ type MultiGeoIPMatcher struct {
 
 var errExpectedIPNonMatch = errors.New("expectIPs not match")
 
+// errQueryStrategyMismatch means the query strategy of a name server excludes all the IP families queried.
+var errQueryStrategyMismatch = errors.New("query strategy not match")
+
 // Match check ip match
 func (c *MultiGeoIPMatcher) Match(ip net.IP) bool {
 	for _, matcher := range c.matchers {
@@ -67,8 +78,9 @@ func generateRandomTag() string {
 // New creates a new DNS server with given configuration.
 func New(ctx context.Context, config *Config) (*Server, error) {
 	server := &Server{
-		clients: make([]Client, 0, len(config.NameServers)+len(config.NameServer)),
-		tag:     config.Tag,
+		clients:       make([]Client, 0, len(config.NameServers)+len(config.NameServer)),
+		queryStrategy: config.QueryStrategy,
+		tag:           config.Tag,
 	}
 	if server.tag == "" {
 		server.tag = generateRandomTag()
@@ -80,16 +92,46 @@ func New(ctx context.Context, config *Config) (*Server, error) {
 		server.clientIP = net.IP(config.ClientIp)
 	}
 
-	hosts, err := NewStaticHosts(config.StaticHosts, config.Hosts)
-	if err != nil {
-		return nil, newError("failed to create hosts").Base(err)
+	if len(config.HostsFile) > 0 {
+		watcher, err := newHostsFileWatcher(config)
+		if err != nil {
+			return nil, newError("failed to create hosts").Base(err)
+		}
+		server.hosts = watcher.hosts
+		server.hostsWatcher = watcher
+	} else {
+		hosts, err := NewStaticHosts(config.StaticHosts, config.Hosts)
+		if err != nil {
+			return nil, newError("failed to create hosts").Base(err)
+		}
+		server.hosts = hosts
 	}
-	server.hosts = hosts
 
-	addNameServer := func(endpoint *net.Endpoint) int {
+	if !config.DisableCache {
+		server.cache = NewCache(config.Cache)
+		common.Must(core.RequireFeatures(ctx, func(sm stats.Manager) {
+			hits, _ := stats.GetOrRegisterCounter(sm, "dns>>>cache>>>hit")
+			misses, _ := stats.GetOrRegisterCounter(sm, "dns>>>cache>>>miss")
+			server.cache.SetCounters(hits, misses)
+		}))
+	}
+
+	addNameServer := func(endpoint *net.Endpoint, disableCache bool) (int, error) {
 		address := endpoint.Address.AsAddress()
+		cache := server.cache
+		if disableCache {
+			cache = nil
+		}
 		if address.Family().IsDomain() && address.Domain() == "localhost" {
 			server.clients = append(server.clients, NewLocalNameServer())
+		} else if address.Family().IsDomain() && address.Domain() == "fakedns" {
+			idx := len(server.clients)
+			server.clients = append(server.clients, nil)
+
+			// need the FakeDNS engine, register FakeDNSServer at callback
+			common.Must(core.RequireFeatures(ctx, func(fd dns.FakeDNSEngine) {
+				server.clients[idx] = NewFakeDNSServer(fd)
+			}))
 		} else if address.Family().IsDomain() && strings.HasPrefix(address.Domain(), "https+local://") {
 			// URI schemed string treated as domain
 			// DOH Local mode
@@ -97,7 +139,7 @@ func New(ctx context.Context, config *Config) (*Server, error) {
 			if err != nil {
 				log.Fatalln(newError("DNS config error").Base(err))
 			}
-			server.clients = append(server.clients, NewDoHLocalNameServer(u, server.clientIP))
+			server.clients = append(server.clients, NewDoHLocalNameServer(u, server.clientIP, cache))
 		} else if address.Family().IsDomain() &&
 			strings.HasPrefix(address.Domain(), "https://") {
 			// DOH Remote mode
@@ -110,7 +152,38 @@ func New(ctx context.Context, config *Config) (*Server, error) {
 
 			// need the core dispatcher, register DOHClient at callback
 			common.Must(core.RequireFeatures(ctx, func(d routing.Dispatcher) {
-				c, err := NewDoHNameServer(u, d, server.clientIP)
+				c, err := NewDoHNameServer(u, d, server.clientIP, cache)
+				if err != nil {
+					log.Fatalln(newError("DNS config error").Base(err))
+				}
+				server.clients[idx] = c
+			}))
+		} else if address.Family().IsDomain() && (strings.HasPrefix(address.Domain(), "quic://") || strings.HasPrefix(address.Domain(), "quic+local://")) {
+			// The bundled QUIC implementation predates RFC 9000, so DNS over QUIC (RFC 9250) can't be served.
+			return 0, newError("DNS over QUIC is not supported: ", address.Domain()).AtWarning()
+		} else if address.Family().IsDomain() && strings.HasPrefix(address.Domain(), "tls+local://") {
+			// DOT Local mode
+			u, err := url.Parse(address.Domain())
+			if err != nil {
+				log.Fatalln(newError("DNS config error").Base(err))
+			}
+			c, err := NewTLSLocalNameServer(u, server.clientIP, cache)
+			if err != nil {
+				log.Fatalln(newError("DNS config error").Base(err))
+			}
+			server.clients = append(server.clients, c)
+		} else if address.Family().IsDomain() && strings.HasPrefix(address.Domain(), "tls://") {
+			// DOT Remote mode
+			u, err := url.Parse(address.Domain())
+			if err != nil {
+				log.Fatalln(newError("DNS config error").Base(err))
+			}
+			idx := len(server.clients)
+			server.clients = append(server.clients, nil)
+
+			// need the core dispatcher, register the client at callback
+			common.Must(core.RequireFeatures(ctx, func(d routing.Dispatcher) {
+				c, err := NewTLSNameServer(u, d, server.clientIP, cache)
 				if err != nil {
 					log.Fatalln(newError("DNS config error").Base(err))
 				}
@@ -127,28 +200,41 @@ func New(ctx context.Context, config *Config) (*Server, error) {
 				server.clients = append(server.clients, nil)
 
 				common.Must(core.RequireFeatures(ctx, func(d routing.Dispatcher) {
-					server.clients[idx] = NewClassicNameServer(dest, d, server.clientIP)
+					server.clients[idx] = NewClassicNameServer(dest, d, server.clientIP, cache)
 				}))
 			}
 		}
-		return len(server.clients) - 1
+		return len(server.clients) - 1, nil
 	}
 
 	if len(config.NameServers) > 0 {
 		features.PrintDeprecatedFeatureWarning("simple DNS server")
 		for _, destPB := range config.NameServers {
-			addNameServer(destPB)
+			if _, err := addNameServer(destPB, false); err != nil {
+				return nil, err
+			}
 		}
 	}
 
+	serverIndices := make(map[string][]uint32)
 	if len(config.NameServer) > 0 {
 		domainMatcher := &strmatcher.MatcherGroup{}
 		domainIndexMap := make(map[uint32]uint32)
 		ipIndexMap := make(map[uint32]*MultiGeoIPMatcher)
+		strategyIndexMap := make(map[uint32]QueryStrategy)
 		var geoIPMatcherContainer router.GeoIPMatcherContainer
 
 		for _, ns := range config.NameServer {
-			idx := addNameServer(ns.Address)
+			idx, err := addNameServer(ns.Address, ns.DisableCache)
+			if err != nil {
+				return nil, err
+			}
+			if ns.QueryStrategy != QueryStrategy_USE_IP {
+				strategyIndexMap[uint32(idx)] = ns.QueryStrategy
+			}
+			if len(ns.Tag) > 0 {
+				serverIndices[ns.Tag] = append(serverIndices[ns.Tag], uint32(idx))
+			}
 
 			for _, domain := range ns.PrioritizedDomain {
 				matcher, err := toStrMatcher(domain.Type, domain.Domain)
@@ -177,6 +263,15 @@ func New(ctx context.Context, config *Config) (*Server, error) {
 		server.domainMatcher = domainMatcher
 		server.domainIndexMap = domainIndexMap
 		server.ipIndexMap = ipIndexMap
+		server.strategyIndexMap = strategyIndexMap
+	}
+
+	for _, r := range config.Rule {
+		rule, err := newQueryRule(r, serverIndices)
+		if err != nil {
+			return nil, newError("failed to create query rule").Base(err).AtWarning()
+		}
+		server.rules = append(server.rules, rule)
 	}
 
 	if len(server.clients) == 0 {
@@ -193,17 +288,24 @@ func (*Server) Type() interface{} {
 
 // Start implements common.Runnable.
 func (s *Server) Start() error {
+	if s.hostsWatcher != nil {
+		return s.hostsWatcher.checker.Start()
+	}
 	return nil
 }
 
 // Close implements common.Closable.
 func (s *Server) Close() error {
+	if s.hostsWatcher != nil {
+		return s.hostsWatcher.checker.Close()
+	}
 	return nil
 }
 
+// IsOwnLink returns true if the link of ctx is dispatched by the DNS app for a query to a name server.
 func (s *Server) IsOwnLink(ctx context.Context) bool {
-	inbound := session.InboundFromContext(ctx)
-	return inbound != nil && inbound.Tag == s.tag
+	content := session.ContentFromContext(ctx)
+	return content != nil && content.Attribute(ownLinkAttribute) != nil
 }
 
 // Match check dns ip match geoip
@@ -231,46 +333,95 @@ func (s *Server) Match(idx uint32, client Client, domain string, ips []net.IP) (
 	return newIps, nil
 }
 
-func (s *Server) queryIPTimeout(idx uint32, client Client, domain string, option IPOption) ([]net.IP, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*4)
-	if len(s.tag) > 0 {
-		ctx = session.ContextWithInbound(ctx, &session.Inbound{
-			Tag: s.tag,
-		})
+// queryContext returns the context of a query sent to a name server. The query is tagged as the inbound of the DNS
+// app, and keeps the source of the client that sends the query in ctx, if any. It takes the inbound tag of the client
+// instead, if the lookup is to be routed as the client's, such as one from a DNS inbound.
+func (s *Server) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	queryCtx, cancel := context.WithTimeout(context.Background(), time.Second*4)
+	inbound := &session.Inbound{
+		Tag: s.tag,
+	}
+	if client := session.InboundFromContext(ctx); client != nil {
+		if len(client.Tag) > 0 && dns.InboundRoutingFromContext(ctx) {
+			inbound.Tag = client.Tag
+		}
+		inbound.Source = client.Source
+	}
+	return session.ContextWithInbound(queryCtx, inbound), cancel
+}
+
+// ownLinkAttribute is the attribute in the content of links that the DNS app dispatches to name servers.
+const ownLinkAttribute = "dns.query"
+
+// linkContext returns the context of a link dispatched to a name server for the query of ctx. The link inherits the
+// inbound of the query, and is marked as the DNS app's own so that DNS outbounds don't resolve it again.
+func linkContext(ctx context.Context, content *session.Content) context.Context {
+	linkCtx := context.Background()
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		linkCtx = session.ContextWithInbound(linkCtx, inbound)
+	}
+	content.SetAttribute(ownLinkAttribute, true)
+	return session.ContextWithContent(linkCtx, content)
+}
+
+// inboundTag returns the inbound tag of the query of ctx, by which name servers separate the links they share among
+// queries.
+func inboundTag(ctx context.Context) string {
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		return inbound.Tag
+	}
+	return ""
+}
+
+func (s *Server) queryIPTimeout(ctx context.Context, idx uint32, client Client, domain string, option IPOption) ([]net.IP, uint32, error) {
+	strategy, found := s.strategyIndexMap[idx]
+	if found {
+		option = option.restrict(strategy)
+		if option.isEmpty() {
+			return nil, 0, errQueryStrategyMismatch
+		}
 	}
-	ips, err := client.QueryIP(ctx, domain, option)
+	if !strategy.isPreference() {
+		strategy = s.queryStrategy
+	}
+
+	queryCtx, cancel := s.queryContext(ctx)
+	ips, ttl, err := client.QueryIP(queryCtx, domain, option)
 	cancel()
 
 	if err != nil {
-		return ips, err
+		return ips, 0, err
 	}
 
 	ips, err = s.Match(idx, client, domain, ips)
-	return ips, err
+	return sortIPs(ips, strategy), ttl, err
 }
 
 // LookupIP implements dns.Client.
 func (s *Server) LookupIP(domain string) ([]net.IP, error) {
-	return s.lookupIPInternal(domain, IPOption{
+	ips, _, err := s.lookupIPInternal(context.Background(), domain, IPOption{
 		IPv4Enable: true,
 		IPv6Enable: true,
 	})
+	return ips, err
 }
 
 // LookupIPv4 implements dns.IPv4Lookup.
 func (s *Server) LookupIPv4(domain string) ([]net.IP, error) {
-	return s.lookupIPInternal(domain, IPOption{
+	ips, _, err := s.lookupIPInternal(context.Background(), domain, IPOption{
 		IPv4Enable: true,
 		IPv6Enable: false,
 	})
+	return ips, err
 }
 
 // LookupIPv6 implements dns.IPv6Lookup.
 func (s *Server) LookupIPv6(domain string) ([]net.IP, error) {
-	return s.lookupIPInternal(domain, IPOption{
+	ips, _, err := s.lookupIPInternal(context.Background(), domain, IPOption{
 		IPv4Enable: false,
 		IPv6Enable: true,
 	})
+	return ips, err
 }
 
 func (s *Server) lookupStatic(domain string, option IPOption, depth int32) []net.Address {
@@ -297,9 +448,15 @@ func toNetIP(ips []net.Address) []net.IP {
 	return netips
 }
 
-func (s *Server) lookupIPInternal(domain string, option IPOption) ([]net.IP, error) {
+// lookupIPInternal returns IPs of the domain along with their TTL in seconds.
+func (s *Server) lookupIPInternal(ctx context.Context, domain string, option IPOption) ([]net.IP, uint32, error) {
 	if domain == "" {
-		return nil, newError("empty domain name")
+		return nil, 0, newError("empty domain name")
+	}
+
+	option = option.restrict(s.queryStrategy)
+	if option.isEmpty() {
+		return nil, 0, dns.ErrEmptyResponse
 	}
 
 	// normalize the FQDN form query
@@ -309,13 +466,13 @@ func (s *Server) lookupIPInternal(domain string, option IPOption) ([]net.IP, err
 
 	// skip domain without any dot
 	if !strings.Contains(domain, ".") {
-		return nil, newError("invalid domain name").AtWarning()
+		return nil, 0, newError("invalid domain name").AtWarning()
 	}
 
 	ips := s.lookupStatic(domain, option, 0)
 	if ips != nil && ips[0].Family().IsIP() {
 		newError("returning ", len(ips), " IPs for domain ", domain).WriteToLog()
-		return toNetIP(ips), nil
+		return sortIPs(toNetIP(ips), s.queryStrategy), defaultTTL, nil
 	}
 
 	if ips != nil && ips[0].Family().IsDomain() {
@@ -324,18 +481,90 @@ func (s *Server) lookupIPInternal(domain string, option IPOption) ([]net.IP, err
 		domain = newdomain
 	}
 
+	if !option.IPv4Enable {
+		return s.queryIPByRule(ctx, domain, option, s.matchRule(ctx, domain, dnsmessage.TypeAAAA))
+	}
+	rule := s.matchRule(ctx, domain, dnsmessage.TypeA)
+	if !option.IPv6Enable {
+		return s.queryIPByRule(ctx, domain, option, rule)
+	}
+	if rule6 := s.matchRule(ctx, domain, dnsmessage.TypeAAAA); rule6 != rule {
+		// A and AAAA records are decided by different rules, so they are looked up separately.
+		ips, ttl, err := s.queryIPByRule(ctx, domain, IPOption{IPv4Enable: true}, rule)
+		ips6, ttl6, err6 := s.queryIPByRule(ctx, domain, IPOption{IPv6Enable: true}, rule6)
+		switch {
+		case len(ips) == 0 && len(ips6) == 0:
+			if err == nil {
+				err = err6
+			}
+			return nil, 0, err
+		case len(ips6) == 0:
+			return ips, ttl, nil
+		case len(ips) == 0:
+			return ips6, ttl6, nil
+		}
+		if ttl6 < ttl {
+			ttl = ttl6
+		}
+		return sortIPs(append(ips, ips6...), s.queryStrategy), ttl, nil
+	}
+	return s.queryIPByRule(ctx, domain, option, rule)
+}
+
+// queryIPByRule queries IPs of the domain as the rule decides. It sends the query in the default way if rule is nil.
+func (s *Server) queryIPByRule(ctx context.Context, domain string, option IPOption, rule *queryRule) ([]net.IP, uint32, error) {
+	if rule != nil {
+		switch rule.action {
+		case QueryAction_NXDOMAIN:
+			newError("blocking domain ", domain, " with NXDOMAIN").AtInfo().WriteToLog()
+			return nil, 0, dns.RCodeError(dnsmessage.RCodeNameError)
+		case QueryAction_ZERO_IP:
+			newError("blocking domain ", domain, " with zero IP").AtInfo().WriteToLog()
+			return blockedIPs(option), defaultTTL, nil
+		}
+		if len(rule.servers) > 0 {
+			return s.queryIPAt(ctx, rule.servers, domain, option)
+		}
+	}
+	return s.queryIP(ctx, domain, option)
+}
+
+// queryIPAt sends the query to the given name servers in order.
+func (s *Server) queryIPAt(ctx context.Context, servers []uint32, domain string, option IPOption) ([]net.IP, uint32, error) {
+	var lastErr error
+	for _, idx := range servers {
+		client := s.clients[idx]
+		ips, ttl, err := s.queryIPTimeout(ctx, idx, client, domain, option)
+		if len(ips) > 0 {
+			return ips, ttl, nil
+		}
+
+		if err != nil {
+			newError("failed to lookup ip for domain ", domain, " at server ", client.Name()).Base(err).WriteToLog()
+			lastErr = err
+		}
+		if err != context.Canceled && err != context.DeadlineExceeded && err != errExpectedIPNonMatch && err != errQueryStrategyMismatch {
+			return nil, 0, err
+		}
+	}
+
+	return nil, 0, newError("returning nil for domain ", domain).Base(lastErr)
+}
+
+// queryIP sends the query to the name server of prioritized domains first, and then to all name servers in order.
+func (s *Server) queryIP(ctx context.Context, domain string, option IPOption) ([]net.IP, uint32, error) {
 	var lastErr error
 	var matchedClient Client
 	if s.domainMatcher != nil {
 		idx := s.domainMatcher.Match(domain)
 		if idx > 0 {
 			matchedClient = s.clients[s.domainIndexMap[idx]]
-			ips, err := s.queryIPTimeout(s.domainIndexMap[idx], matchedClient, domain, option)
+			ips, ttl, err := s.queryIPTimeout(ctx, s.domainIndexMap[idx], matchedClient, domain, option)
 			if len(ips) > 0 {
-				return ips, nil
+				return ips, ttl, nil
 			}
 			if err == dns.ErrEmptyResponse {
-				return nil, err
+				return nil, 0, err
 			}
 			if err != nil {
 				newError("failed to lookup ip for domain ", domain, " at server ", matchedClient.Name()).Base(err).WriteToLog()
@@ -350,21 +579,21 @@ func (s *Server) lookupIPInternal(domain string, option IPOption) ([]net.IP, err
 			continue
 		}
 
-		ips, err := s.queryIPTimeout(uint32(idx), client, domain, option)
+		ips, ttl, err := s.queryIPTimeout(ctx, uint32(idx), client, domain, option)
 		if len(ips) > 0 {
-			return ips, nil
+			return ips, ttl, nil
 		}
 
 		if err != nil {
 			newError("failed to lookup ip for domain ", domain, " at server ", client.Name()).Base(err).WriteToLog()
 			lastErr = err
 		}
-		if err != context.Canceled && err != context.DeadlineExceeded && err != errExpectedIPNonMatch {
-			return nil, err
+		if err != context.Canceled && err != context.DeadlineExceeded && err != errExpectedIPNonMatch && err != errQueryStrategyMismatch {
+			return nil, 0, err
 		}
 	}
 
-	return nil, newError("returning nil for domain ", domain).Base(lastErr)
+	return nil, 0, newError("returning nil for domain ", domain).Base(lastErr)
 }
 
 func init() {
@@ -1,11 +1,13 @@
 package dns_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/miekg/dns"
+	"golang.org/x/net/dns/dnsmessage"
 
 	"v2ray.com/core"
 	"v2ray.com/core/app/dispatcher"
@@ -17,7 +19,9 @@ import (
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/serial"
+	"v2ray.com/core/common/session"
 	feature_dns "v2ray.com/core/features/dns"
+	"v2ray.com/core/proxy/blackhole"
 	"v2ray.com/core/proxy/freedom"
 	"v2ray.com/core/testing/servers/udp"
 )
@@ -363,6 +367,144 @@ func TestUDPServerIPv6(t *testing.T) {
 	}
 }
 
+func TestQueryStrategy(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	newClient := func(strategy QueryStrategy, serverStrategy QueryStrategy) feature_dns.Client {
+		config := &core.Config{
+			App: []*serial.TypedMessage{
+				serial.ToTypedMessage(&Config{
+					NameServer: []*NameServer{
+						{
+							Address: &net.Endpoint{
+								Network: net.Network_UDP,
+								Address: &net.IPOrDomain{
+									Address: &net.IPOrDomain_Ip{
+										Ip: []byte{127, 0, 0, 1},
+									},
+								},
+								Port: uint32(port),
+							},
+							QueryStrategy: serverStrategy,
+						},
+					},
+					QueryStrategy: strategy,
+				}),
+				serial.ToTypedMessage(&dispatcher.Config{}),
+				serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+				serial.ToTypedMessage(&policy.Config{}),
+			},
+			Outbound: []*core.OutboundHandlerConfig{
+				{
+					ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+				},
+			},
+		}
+
+		v, err := core.New(config)
+		common.Must(err)
+		return v.GetFeature(feature_dns.ClientType()).(feature_dns.Client)
+	}
+
+	ipv4 := net.IP{8, 8, 8, 7}
+	ipv6 := net.IP{32, 1, 72, 96, 72, 96, 0, 0, 0, 0, 0, 0, 0, 0, 136, 136}
+
+	{
+		client := newClient(QueryStrategy_PREFER_IP6, QueryStrategy_USE_IP)
+		ips, err := client.LookupIP("ipv6.google.com")
+		if err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+		if r := cmp.Diff(ips, []net.IP{ipv6, ipv4}); r != "" {
+			t.Fatal(r)
+		}
+	}
+
+	{
+		client := newClient(QueryStrategy_USE_IP6, QueryStrategy_USE_IP)
+		_, err := client.(feature_dns.IPv4Lookup).LookupIPv4("ipv6.google.com")
+		if err != feature_dns.ErrEmptyResponse {
+			t.Fatal("expected empty response, but got ", err)
+		}
+	}
+
+	{
+		client := newClient(QueryStrategy_PREFER_IP6, QueryStrategy_USE_IP4)
+		ips, err := client.LookupIP("ipv6.google.com")
+		if err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+		if r := cmp.Diff(ips, []net.IP{ipv4}); r != "" {
+			t.Fatal(r)
+		}
+	}
+}
+
+func TestQueryStrategySkipsServer(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	endpoint := &net.Endpoint{
+		Network: net.Network_UDP,
+		Address: net.NewIPOrDomain(net.LocalHostIP),
+		Port:    uint32(port),
+	}
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				NameServer: []*NameServer{
+					{
+						Address:       endpoint,
+						QueryStrategy: QueryStrategy_USE_IP6,
+					},
+					{
+						Address: endpoint,
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+
+	client := v.GetFeature(feature_dns.ClientType()).(feature_dns.Client)
+	ips, err := client.(feature_dns.IPv4Lookup).LookupIPv4("ipv6.google.com")
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	if r := cmp.Diff(ips, []net.IP{{8, 8, 8, 7}}); r != "" {
+		t.Fatal(r)
+	}
+}
+
 func TestStaticHostDomain(t *testing.T) {
 	port := udp.PickPort()
 
@@ -537,3 +679,270 @@ func TestIPMatch(t *testing.T) {
 		t.Error("DNS query doesn't finish in 2 seconds.")
 	}
 }
+
+func TestQueryRule(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+
+	go dnsServer.ListenAndServe()
+
+	// corpServer answers 10.0.0.8 to all A queries.
+	corpPort := udp.PickPort()
+	corpServer := dns.Server{
+		Addr: "127.0.0.1:" + corpPort.String(),
+		Net:  "udp",
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			ans := new(dns.Msg)
+			ans.SetReply(r)
+			for _, q := range r.Question {
+				if q.Qtype == dns.TypeA {
+					rr, _ := dns.NewRR(q.Name + " IN A 10.0.0.8")
+					ans.Answer = append(ans.Answer, rr)
+				}
+			}
+			w.WriteMsg(ans)
+		}),
+		UDPSize: 1200,
+	}
+
+	go corpServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				NameServer: []*NameServer{
+					{
+						Address: &net.Endpoint{
+							Network: net.Network_UDP,
+							Address: net.NewIPOrDomain(net.LocalHostIP),
+							Port:    uint32(port),
+						},
+					},
+					{
+						Address: &net.Endpoint{
+							Network: net.Network_UDP,
+							Address: net.NewIPOrDomain(net.LocalHostIP),
+							Port:    uint32(corpPort),
+						},
+						Tag: "corp",
+					},
+				},
+				Rule: []*QueryRule{
+					{
+						Domain: []*router.Domain{{Type: router.Domain_Domain, Value: "ads.v2ray.com"}},
+						Action: QueryAction_NXDOMAIN,
+					},
+					{
+						Domain:    []*router.Domain{{Type: router.Domain_Full, Value: "ipv6.google.com"}},
+						QueryType: []uint32{uint32(dns.TypeAAAA)},
+						Action:    QueryAction_ZERO_IP,
+					},
+					{
+						Domain:    []*router.Domain{{Type: router.Domain_Domain, Value: "corp.v2ray.com"}},
+						ServerTag: "corp",
+					},
+					{
+						InboundTag: []string{"corp-in"},
+						ServerTag:  "corp",
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+
+	client := v.GetFeature(feature_dns.ClientType()).(feature_dns.Client)
+
+	{
+		_, err := client.LookupIP("www.ads.v2ray.com")
+		if rcode := feature_dns.RCodeFromError(err); rcode != uint16(dnsmessage.RCodeNameError) {
+			t.Error("expected NXDOMAIN, but got ", err)
+		}
+	}
+
+	{
+		ips, err := client.LookupIP("ipv6.google.com")
+		if err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+		if r := cmp.Diff(ips, []net.IP{{8, 8, 8, 7}, make(net.IP, net.IPv6len)}); r != "" {
+			t.Error(r)
+		}
+	}
+
+	{
+		ips, err := client.LookupIP("www.corp.v2ray.com")
+		if err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+		if r := cmp.Diff(ips, []net.IP{{10, 0, 0, 8}}); r != "" {
+			t.Error(r)
+		}
+	}
+
+	{
+		lookup := client.(feature_dns.RecordLookup)
+		answers, err := lookup.LookupRecords(context.Background(), "facebook.com", dnsmessage.TypeA)
+		if err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+		if r := cmp.Diff(answers[0].Body, &dnsmessage.AResource{A: [4]byte{9, 9, 9, 9}}); r != "" {
+			t.Error(r)
+		}
+
+		ctx := session.ContextWithInbound(context.Background(), &session.Inbound{Tag: "corp-in"})
+		answers, err = lookup.LookupRecords(ctx, "facebook.com", dnsmessage.TypeA)
+		if err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+		if r := cmp.Diff(answers[0].Body, &dnsmessage.AResource{A: [4]byte{10, 0, 0, 8}}); r != "" {
+			t.Error(r)
+		}
+	}
+}
+
+func TestQueryRoutedByInbound(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				NameServer: []*NameServer{
+					{
+						Address: &net.Endpoint{
+							Network: net.Network_UDP,
+							Address: net.NewIPOrDomain(net.LocalHostIP),
+							Port:    uint32(port),
+						},
+					},
+				},
+			}),
+			serial.ToTypedMessage(&router.Config{
+				Rule: []*router.RoutingRule{
+					{
+						InboundTag: []string{"dns-in"},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "direct",
+						},
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				Tag:           "blocked",
+				ProxySettings: serial.ToTypedMessage(&blackhole.Config{}),
+			},
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+
+	lookup := v.GetFeature(feature_dns.ClientType()).(feature_dns.RecordLookup)
+
+	// Queries of lookups routed by inbound, such as those from DNS inbounds, take the inbound tag of the client.
+	ctx := feature_dns.ContextWithInboundRouting(session.ContextWithInbound(context.Background(), &session.Inbound{Tag: "dns-in"}))
+	answers, err := lookup.LookupRecords(ctx, "facebook.com", dnsmessage.TypeA)
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	if r := cmp.Diff(answers[0].Body, &dnsmessage.AResource{A: [4]byte{9, 9, 9, 9}}); r != "" {
+		t.Error(r)
+	}
+
+	// Other queries are tagged as the DNS app, and go to the default outbound, which blocks them.
+	ctx = session.ContextWithInbound(context.Background(), &session.Inbound{Tag: "dns-in"})
+	if _, err := lookup.LookupRecords(ctx, "google.com", dnsmessage.TypeA); err == nil {
+		t.Error("expected query to be blocked")
+	}
+}
+
+func TestQueryRuleWithoutCondition(t *testing.T) {
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				NameServer: []*NameServer{
+					{
+						Address: &net.Endpoint{
+							Network: net.Network_UDP,
+							Address: net.NewIPOrDomain(net.LocalHostIP),
+							Port:    53,
+						},
+					},
+				},
+				Rule: []*QueryRule{
+					{
+						Action: QueryAction_NXDOMAIN,
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+	}
+
+	if _, err := core.New(config); err == nil {
+		t.Error("expected an error for rule without condition")
+	}
+}
+
+func TestQUICNameServerUnsupported(t *testing.T) {
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				NameServer: []*NameServer{
+					{
+						Address: &net.Endpoint{
+							Network: net.Network_UDP,
+							Address: net.NewIPOrDomain(net.DomainAddress("quic://dns.adguard.com")),
+							Port:    853,
+						},
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+	}
+
+	if _, err := core.New(config); err == nil {
+		t.Error("expected an error for DNS over QUIC name server")
+	}
+}
@@ -0,0 +1,177 @@
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol/dns"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/common/signal/pubsub"
+)
+
+// streamNameServer is the common part of name servers that send each query over a stream of an encrypted
+// connection and read the response from the same stream, such as DNS over TLS.
+type streamNameServer struct {
+	name     string
+	cache    *Cache
+	pub      *pubsub.Service
+	reqID    uint32
+	clientIP net.IP
+	// exchange sends a packed query, and returns the packed response. It takes the ownership of the query.
+	exchange func(ctx context.Context, query *buf.Buffer) ([]byte, error)
+}
+
+func newStreamNameServer(name string, clientIP net.IP, cache *Cache) streamNameServer {
+	return streamNameServer{
+		name:     name,
+		cache:    cache,
+		pub:      pubsub.NewService(),
+		clientIP: clientIP,
+	}
+}
+
+// Name implements Client.
+func (s *streamNameServer) Name() string {
+	return s.name
+}
+
+func (s *streamNameServer) newReqID() uint16 {
+	return uint16(atomic.AddUint32(&s.reqID, 1))
+}
+
+func (s *streamNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
+	elapsed := time.Since(req.start)
+	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
+
+	var rec record
+	switch req.reqType {
+	case dnsmessage.TypeA:
+		rec.A = ipRec
+	case dnsmessage.TypeAAAA:
+		rec.AAAA = ipRec
+	}
+	if s.cache != nil {
+		s.cache.Update(s.name+" "+req.domain, rec)
+	}
+	switch req.reqType {
+	case dnsmessage.TypeA:
+		s.pub.Publish(req.domain+"4", ipRec)
+	case dnsmessage.TypeAAAA:
+		s.pub.Publish(req.domain+"6", ipRec)
+	}
+}
+
+func (s *streamNameServer) sendQuery(ctx context.Context, domain string, option IPOption) {
+	newError(s.name, " querying: ", domain).AtInfo().WriteToLog(session.ExportIDToError(ctx))
+
+	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(s.clientIP))
+
+	var deadline time.Time
+	if d, ok := ctx.Deadline(); ok {
+		deadline = d
+	} else {
+		deadline = time.Now().Add(time.Second * 8)
+	}
+
+	for _, req := range reqs {
+		go func(r *dnsRequest) {
+			// each request has its own context, so that a failed one doesn't abort the others
+			dnsCtx := linkContext(ctx, &session.Content{
+				Protocol: "dns",
+			})
+			dnsCtx, cancel := context.WithDeadline(dnsCtx, deadline)
+			defer cancel()
+
+			b, err := dns.PackMessage(r.msg)
+			if err != nil {
+				newError("failed to pack dns query").Base(err).AtError().WriteToLog()
+				return
+			}
+			resp, err := s.exchange(dnsCtx, b)
+			if err != nil {
+				newError(s.name, " failed to retrieve response").Base(err).AtError().WriteToLog()
+				return
+			}
+			rec, err := parseResponse(resp)
+			if err != nil {
+				newError(s.name, " failed to handle response").Base(err).AtError().WriteToLog()
+				return
+			}
+			s.updateIP(r, rec)
+		}(req)
+	}
+}
+
+// QueryIP implements Client.
+func (s *streamNameServer) QueryIP(ctx context.Context, domain string, option IPOption) ([]net.IP, uint32, error) {
+	fqdn := Fqdn(domain)
+
+	if s.cache != nil {
+		ips, ttl, refresh, err := s.cache.Lookup(s.name+" "+fqdn, option)
+		if err != errRecordNotFound {
+			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
+			if refresh {
+				newError(s.name, " refreshing stale record of ", domain).AtDebug().WriteToLog()
+				s.sendQuery(ctx, fqdn, option)
+			}
+			return ips, ttl, err
+		}
+	}
+
+	sub4, sub6 := subscribeRecords(s.pub, fqdn, option)
+	if sub4 != nil {
+		defer sub4.Close()
+	}
+	if sub6 != nil {
+		defer sub6.Close()
+	}
+	s.sendQuery(ctx, fqdn, option)
+
+	return waitRecords(ctx, sub4, sub6, option)
+}
+
+// QueryRecords implements recordQuerier.
+func (s *streamNameServer) QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	newError(s.name, " querying ", qType, ": ", domain).AtInfo().WriteToLog(session.ExportIDToError(ctx))
+
+	msg, err := buildRecordQuery(domain, qType, s.newReqID(), genEDNS0Options(s.clientIP))
+	if err != nil {
+		return nil, err
+	}
+	b, err := dns.PackMessage(msg)
+	if err != nil {
+		return nil, newError("failed to pack dns query").Base(err)
+	}
+	content := &session.Content{
+		Protocol: "dns",
+	}
+	content.SetAttribute(ownLinkAttribute, true)
+	resp, err := s.exchange(session.ContextWithContent(ctx, content), b)
+	if err != nil {
+		return nil, newError(s.name, " failed to retrieve response").Base(err)
+	}
+
+	return parseRecords(resp)
+}
+
+// readMessage reads a message with a length prefix. Unlike dns.TCPReader, it keeps the data that comes along with
+// the end of stream, and it accepts messages of any size up to the 65535 bytes allowed by the prefix.
+func readMessage(reader io.Reader) ([]byte, error) {
+	var size [2]byte
+	if _, err := io.ReadFull(reader, size[:]); err != nil {
+		return nil, err
+	}
+	b := make([]byte, binary.BigEndian.Uint16(size[:]))
+	if _, err := io.ReadFull(reader, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
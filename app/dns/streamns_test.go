@@ -0,0 +1,168 @@
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol/tls/cert"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/testing/servers/tcp"
+	v2tls "v2ray.com/core/transport/internet/tls"
+)
+
+func newTestTLSConfig(nextProtos ...string) *tls.Config {
+	config := (&v2tls.Config{
+		Certificate: []*v2tls.Certificate{v2tls.ParseCertificate(cert.MustGenerate(nil, cert.DNSNames("dns.v2ray.com"), cert.CommonName("dns.v2ray.com")))},
+	}).GetTLSConfig()
+	config.NextProtos = nextProtos
+	return config
+}
+
+// answer replies A queries with 1.2.3.4, and AAAA queries with nothing.
+func answer(r *dns.Msg) *dns.Msg {
+	ans := new(dns.Msg)
+	ans.SetReply(r)
+	for _, q := range r.Question {
+		if q.Qtype == dns.TypeA {
+			rr, err := dns.NewRR(q.Name + " IN A 1.2.3.4")
+			common.Must(err)
+			ans.Answer = append(ans.Answer, rr)
+		}
+	}
+	return ans
+}
+
+func checkStreamNameServer(t *testing.T, c Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*4)
+	defer cancel()
+
+	ips, _, err := c.QueryIP(ctx, "www.v2ray.com", IPOption{IPv4Enable: true, IPv6Enable: true})
+	common.Must(err)
+	if len(ips) != 1 || ips[0].String() != "1.2.3.4" {
+		t.Error("unexpected IPs: ", ips)
+	}
+}
+
+// countingListener counts the connections it accepts.
+type countingListener struct {
+	net.Listener
+	count int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		atomic.AddInt32(&l.count, 1)
+	}
+	return conn, err
+}
+
+func TestTLSNameServer(t *testing.T) {
+	port := tcp.PickPort()
+	tlsListener, err := tls.Listen("tcp", net.TCPDestination(net.LocalHostIP, port).NetAddr(), newTestTLSConfig())
+	common.Must(err)
+	listener := &countingListener{Listener: tlsListener}
+	server := &dns.Server{
+		Listener: listener,
+		Net:      "tcp-tls",
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			common.Must(w.WriteMsg(answer(r)))
+		}),
+	}
+	go server.ActivateAndServe() // nolint: errcheck
+	defer server.Shutdown()      // nolint: errcheck
+
+	u, err := url.Parse("tls+local://127.0.0.1:" + port.String())
+	common.Must(err)
+	s, err := NewTLSLocalNameServer(u, nil, nil)
+	common.Must(err)
+	s.tlsConfig.InsecureSkipVerify = true
+
+	checkStreamNameServer(t, s)
+
+	// Queries after the first one reuse its connection.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*4)
+	defer cancel()
+	for _, domain := range []string{"a.v2ray.com", "b.v2ray.com"} {
+		ips, _, err := s.QueryIP(ctx, domain, IPOption{IPv4Enable: true, IPv6Enable: true})
+		common.Must(err)
+		if len(ips) != 1 || ips[0].String() != "1.2.3.4" {
+			t.Error("unexpected IPs: ", ips)
+		}
+	}
+	if count := atomic.LoadInt32(&listener.count); count != 1 {
+		t.Error("expected 1 connection, but got ", count)
+	}
+
+	// A closed connection is replaced by a new one.
+	s.conns[""].conn.Close()
+	checkStreamNameServer(t, s)
+	if count := atomic.LoadInt32(&listener.count); count != 2 {
+		t.Error("expected 2 connections, but got ", count)
+	}
+
+	// Queries from another inbound have a connection of their own, as connections are routed by inbound.
+	_, _, err = s.QueryIP(session.ContextWithInbound(ctx, &session.Inbound{Tag: "in"}), "c.v2ray.com", IPOption{IPv4Enable: true})
+	common.Must(err)
+	if count := atomic.LoadInt32(&listener.count); count != 3 {
+		t.Error("expected 3 connections, but got ", count)
+	}
+}
+
+func TestTLSNameServerLargeResponse(t *testing.T) {
+	port := tcp.PickPort()
+	tlsListener, err := tls.Listen("tcp", net.TCPDestination(net.LocalHostIP, port).NetAddr(), newTestTLSConfig())
+	common.Must(err)
+	listener := &countingListener{Listener: tlsListener}
+	server := &dns.Server{
+		Listener: listener,
+		Net:      "tcp-tls",
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			ans := new(dns.Msg)
+			ans.SetReply(r)
+			if q := r.Question[0]; q.Qtype == dns.TypeA && q.Name == "large.v2ray.com." {
+				// 256 records make a response larger than buf.Size.
+				for i := 0; i < 256; i++ {
+					rr, err := dns.NewRR(q.Name + " IN A 10.0.1." + strconv.Itoa(i))
+					common.Must(err)
+					ans.Answer = append(ans.Answer, rr)
+				}
+			} else {
+				ans = answer(r)
+			}
+			common.Must(w.WriteMsg(ans))
+		}),
+	}
+	go server.ActivateAndServe() // nolint: errcheck
+	defer server.Shutdown()      // nolint: errcheck
+
+	u, err := url.Parse("tls+local://127.0.0.1:" + port.String())
+	common.Must(err)
+	s, err := NewTLSLocalNameServer(u, nil, nil)
+	common.Must(err)
+	s.tlsConfig.InsecureSkipVerify = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*4)
+	defer cancel()
+	ips, _, err := s.QueryIP(ctx, "large.v2ray.com", IPOption{IPv4Enable: true})
+	common.Must(err)
+	if len(ips) != 256 {
+		t.Error("expected 256 IPs, but got ", len(ips))
+	}
+
+	// The connection is still usable after the large response.
+	checkStreamNameServer(t, s)
+	if count := atomic.LoadInt32(&listener.count); count != 1 {
+		t.Error("expected 1 connection, but got ", count)
+	}
+}
@@ -0,0 +1,296 @@
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/errors"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol/dns"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/common/signal"
+	"v2ray.com/core/features/routing"
+	"v2ray.com/core/transport/internet"
+)
+
+// dotIdleTimeout is the time after which an idle connection to a DNS over TLS server is closed.
+const dotIdleTimeout = time.Second * 30
+
+var errConnClosed = errors.New("connection closed")
+
+// TLSNameServer implements DNS over TLS (RFC 7858). Queries are pipelined over persistent TLS connections, and
+// responses are matched to them by message ID.
+type TLSNameServer struct {
+	streamNameServer
+	access      sync.Mutex
+	destination net.Destination
+	tlsConfig   *tls.Config
+	dial        func(ctx context.Context, dest net.Destination) (net.Conn, error)
+	// conns are the connections by inbound tags of queries, as a dispatched connection is routed only once.
+	conns map[string]*dotConn
+}
+
+// parseServerURL returns the destination of a name server URL, such as "tls://1.1.1.1" or "tls://dns.example:853".
+func parseServerURL(u *url.URL, network net.Network, defaultPort net.Port) (net.Destination, error) {
+	if len(u.Hostname()) == 0 {
+		return net.Destination{}, newError("host is not specified in ", u.String())
+	}
+	port := defaultPort
+	if len(u.Port()) > 0 {
+		p, err := strconv.ParseUint(u.Port(), 10, 16)
+		if err != nil {
+			return net.Destination{}, newError("invalid port in ", u.String()).Base(err)
+		}
+		port = net.Port(p)
+	}
+	return net.Destination{
+		Network: network,
+		Address: net.ParseAddress(u.Hostname()),
+		Port:    port,
+	}, nil
+}
+
+func baseTLSNameServer(u *url.URL, prefix string, clientIP net.IP, cache *Cache) (*TLSNameServer, error) {
+	dest, err := parseServerURL(u, net.Network_TCP, net.Port(853))
+	if err != nil {
+		return nil, err
+	}
+	s := &TLSNameServer{
+		streamNameServer: newStreamNameServer(prefix+"//"+dest.NetAddr(), clientIP, cache),
+		destination:      dest,
+		conns:            make(map[string]*dotConn),
+		tlsConfig: &tls.Config{
+			ServerName: u.Hostname(),
+		},
+	}
+	s.exchange = s.exchangeTLS
+	return s, nil
+}
+
+// NewTLSNameServer creates a DNS over TLS client, whose connections are dispatched to outbounds.
+func NewTLSNameServer(u *url.URL, dispatcher routing.Dispatcher, clientIP net.IP, cache *Cache) (*TLSNameServer, error) {
+	s, err := baseTLSNameServer(u, "DOT", clientIP, cache)
+	if err != nil {
+		return nil, err
+	}
+	s.dial = func(ctx context.Context, dest net.Destination) (net.Conn, error) {
+		// The link outlives the query that creates the connection, so it only inherits the inbound and content.
+		linkCtx := context.Background()
+		if inbound := session.InboundFromContext(ctx); inbound != nil {
+			linkCtx = session.ContextWithInbound(linkCtx, inbound)
+		}
+		if content := session.ContentFromContext(ctx); content != nil {
+			linkCtx = session.ContextWithContent(linkCtx, content)
+		}
+		link, err := dispatcher.Dispatch(linkCtx, dest)
+		if err != nil {
+			return nil, err
+		}
+		return net.NewConnection(
+			net.ConnectionInputMulti(link.Writer),
+			net.ConnectionOutputMulti(link.Reader),
+		), nil
+	}
+	newError("DNS: created Remote DOT client for ", u.String()).AtInfo().WriteToLog()
+	return s, nil
+}
+
+// NewTLSLocalNameServer creates a DNS over TLS client, which connects to the server directly.
+func NewTLSLocalNameServer(u *url.URL, clientIP net.IP, cache *Cache) (*TLSNameServer, error) {
+	s, err := baseTLSNameServer(u, "DOTL", clientIP, cache)
+	if err != nil {
+		return nil, err
+	}
+	s.dial = func(ctx context.Context, dest net.Destination) (net.Conn, error) {
+		return internet.DialSystem(ctx, dest, nil)
+	}
+	newError("DNS: created Local DOT client for ", u.String()).AtInfo().WriteToLog()
+	return s, nil
+}
+
+// getConn returns the connection to the server for the query of ctx, dialing a new one if there is none. fresh is
+// true for a new one.
+func (s *TLSNameServer) getConn(ctx context.Context) (conn *dotConn, fresh bool, err error) {
+	tag := inboundTag(ctx)
+
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	if conn, found := s.conns[tag]; found {
+		if !conn.isClosed() {
+			return conn, false, nil
+		}
+		delete(s.conns, tag)
+	}
+
+	rawConn, err := s.dial(ctx, s.destination)
+	if err != nil {
+		return nil, false, err
+	}
+	tlsConn := tls.Client(rawConn, s.tlsConfig)
+
+	finished := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			tlsConn.Close()
+		case <-finished:
+		}
+	}()
+	err = tlsConn.Handshake()
+	close(finished)
+	if err != nil {
+		tlsConn.Close()
+		if ctx.Err() != nil {
+			return nil, false, ctx.Err()
+		}
+		return nil, false, newError("failed to complete TLS handshake").Base(err)
+	}
+
+	conn = newDoTConn(tlsConn)
+	s.conns[tag] = conn
+	return conn, true, nil
+}
+
+func (s *TLSNameServer) exchangeTLS(ctx context.Context, query *buf.Buffer) ([]byte, error) {
+	defer query.Release()
+	if query.Len() < 2 {
+		return nil, newError("invalid DNS query")
+	}
+	id := binary.BigEndian.Uint16(query.BytesTo(2))
+
+	for {
+		conn, fresh, err := s.getConn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b := buf.New()
+		b.Write(query.Bytes())
+		resp, err := conn.exchange(ctx, id, b)
+		// The server may close an idle connection while the query is sent. Retry once on a new connection then.
+		if err == errConnClosed && !fresh {
+			continue
+		}
+		return resp, err
+	}
+}
+
+// dotConn is a connection to a DNS over TLS server, with queries waiting for responses on it.
+type dotConn struct {
+	conn        net.Conn
+	writeAccess sync.Mutex
+	writer      *dns.TCPWriter
+	access      sync.Mutex
+	pending     map[uint16]chan []byte
+	closed      bool
+	timer       *signal.ActivityTimer
+}
+
+func newDoTConn(conn net.Conn) *dotConn {
+	c := &dotConn{
+		conn:    conn,
+		writer:  &dns.TCPWriter{Writer: buf.NewWriter(conn)},
+		pending: make(map[uint16]chan []byte),
+	}
+	c.timer = signal.CancelAfterInactivity(context.Background(), func() {
+		c.Close()
+	}, dotIdleTimeout)
+	go c.readResponses()
+	return c
+}
+
+func (c *dotConn) isClosed() bool {
+	c.access.Lock()
+	defer c.access.Unlock()
+	return c.closed
+}
+
+// exchange sends the query of the id, and waits for its response. It takes the ownership of the query.
+func (c *dotConn) exchange(ctx context.Context, id uint16, query *buf.Buffer) ([]byte, error) {
+	ch := make(chan []byte, 1)
+	c.access.Lock()
+	if c.closed {
+		c.access.Unlock()
+		query.Release()
+		return nil, errConnClosed
+	}
+	c.pending[id] = ch
+	c.access.Unlock()
+
+	defer func() {
+		c.access.Lock()
+		if c.pending[id] == ch {
+			delete(c.pending, id)
+		}
+		c.access.Unlock()
+	}()
+
+	c.writeAccess.Lock()
+	err := c.writer.WriteMessage(query)
+	c.writeAccess.Unlock()
+	if err != nil {
+		c.Close()
+		return nil, errConnClosed
+	}
+	c.timer.Update()
+
+	select {
+	case resp := <-ch:
+		if resp == nil {
+			return nil, errConnClosed
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *dotConn) readResponses() {
+	defer c.Close()
+
+	for {
+		b, err := readMessage(c.conn)
+		if err != nil {
+			return
+		}
+		c.timer.Update()
+		if len(b) < 2 {
+			continue
+		}
+		id := binary.BigEndian.Uint16(b[:2])
+
+		c.access.Lock()
+		ch, found := c.pending[id]
+		delete(c.pending, id)
+		c.access.Unlock()
+
+		if found {
+			ch <- b
+		}
+	}
+}
+
+// Close closes the connection, and fails the queries waiting on it.
+func (c *dotConn) Close() error {
+	c.access.Lock()
+	if c.closed {
+		c.access.Unlock()
+		return nil
+	}
+	c.closed = true
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+	c.access.Unlock()
+
+	return c.conn.Close()
+}
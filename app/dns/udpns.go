@@ -4,6 +4,7 @@ package dns
 
 import (
 	"context"
+	"encoding/binary"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -17,25 +18,29 @@ import (
 	"v2ray.com/core/common/session"
 	"v2ray.com/core/common/signal/pubsub"
 	"v2ray.com/core/common/task"
-	dns_feature "v2ray.com/core/features/dns"
 	"v2ray.com/core/features/routing"
 	"v2ray.com/core/transport/internet/udp"
 )
 
 type ClassicNameServer struct {
 	sync.RWMutex
-	name      string
-	address   net.Destination
-	ips       map[string]record
-	requests  map[uint16]dnsRequest
-	pub       *pubsub.Service
-	udpServer *udp.Dispatcher
-	cleanup   *task.Periodic
-	reqID     uint32
-	clientIP  net.IP
+	name     string
+	address  net.Destination
+	cache    *Cache
+	requests map[uint16]dnsRequest
+	// recordRequests holds pending queries of QueryRecords, which take raw responses.
+	recordRequests map[uint16]chan []byte
+	pub            *pubsub.Service
+	dispatcher     routing.Dispatcher
+	// udpServers are the dispatchers of queries by their inbound tags, as a UDP link is routed only once.
+	udpServers map[string]*udp.Dispatcher
+	cleanup    *task.Periodic
+	reqID      uint32
+	clientIP   net.IP
 }
 
-func NewClassicNameServer(address net.Destination, dispatcher routing.Dispatcher, clientIP net.IP) *ClassicNameServer {
+// NewClassicNameServer creates a name server over UDP. Answers are cached in the given cache, unless it is nil.
+func NewClassicNameServer(address net.Destination, dispatcher routing.Dispatcher, clientIP net.IP, cache *Cache) *ClassicNameServer {
 
 	// default to 53 if unspecific
 	if address.Port == 0 {
@@ -43,54 +48,51 @@ func NewClassicNameServer(address net.Destination, dispatcher routing.Dispatcher
 	}
 
 	s := &ClassicNameServer{
-		address:  address,
-		ips:      make(map[string]record),
-		requests: make(map[uint16]dnsRequest),
-		clientIP: clientIP,
-		pub:      pubsub.NewService(),
-		name:     strings.ToUpper(address.String()),
+		address:        address,
+		cache:          cache,
+		requests:       make(map[uint16]dnsRequest),
+		recordRequests: make(map[uint16]chan []byte),
+		dispatcher:     dispatcher,
+		udpServers:     make(map[string]*udp.Dispatcher),
+		clientIP:       clientIP,
+		pub:            pubsub.NewService(),
+		name:           strings.ToUpper(address.String()),
 	}
 	s.cleanup = &task.Periodic{
 		Interval: time.Minute,
 		Execute:  s.Cleanup,
 	}
-	s.udpServer = udp.NewDispatcher(dispatcher, s.HandleResponse)
 	newError("DNS: created udp client inited for ", address.NetAddr()).AtInfo().WriteToLog()
 	return s
 }
 
+func (s *ClassicNameServer) getUDPServer(ctx context.Context) *udp.Dispatcher {
+	tag := inboundTag(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if server, found := s.udpServers[tag]; found {
+		return server
+	}
+	server := udp.NewDispatcher(s.dispatcher, s.HandleResponse)
+	s.udpServers[tag] = server
+	return server
+}
+
 func (s *ClassicNameServer) Name() string {
 	return s.name
 }
 
+// Cleanup removes expired pending requests.
 func (s *ClassicNameServer) Cleanup() error {
 	now := time.Now()
 	s.Lock()
 	defer s.Unlock()
 
-	if len(s.ips) == 0 && len(s.requests) == 0 {
+	if len(s.requests) == 0 {
 		return newError(s.name, " nothing to do. stopping...")
 	}
 
-	for domain, record := range s.ips {
-		if record.A != nil && record.A.Expire.Before(now) {
-			record.A = nil
-		}
-		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
-			record.AAAA = nil
-		}
-
-		if record.A == nil && record.AAAA == nil {
-			delete(s.ips, domain)
-		} else {
-			s.ips[domain] = record
-		}
-	}
-
-	if len(s.ips) == 0 {
-		s.ips = make(map[string]record)
-	}
-
 	for id, req := range s.requests {
 		if req.expire.Before(now) {
 			delete(s.requests, id)
@@ -105,6 +107,17 @@ func (s *ClassicNameServer) Cleanup() error {
 }
 
 func (s *ClassicNameServer) HandleResponse(ctx context.Context, packet *udp_proto.Packet) {
+	if payload := packet.Payload.Bytes(); len(payload) >= 2 {
+		id := binary.BigEndian.Uint16(payload)
+		s.Lock()
+		ch, found := s.recordRequests[id]
+		delete(s.recordRequests, id)
+		s.Unlock()
+		if found {
+			ch <- append([]byte(nil), payload...)
+			return
+		}
+	}
 
 	ipRec, err := parseResponse(packet.Payload.Bytes())
 	if err != nil {
@@ -141,32 +154,16 @@ func (s *ClassicNameServer) HandleResponse(ctx context.Context, packet *udp_prot
 }
 
 func (s *ClassicNameServer) updateIP(domain string, newRec record) {
-	s.Lock()
-
 	newError(s.name, " updating IP records for domain:", domain).AtDebug().WriteToLog()
-	rec := s.ips[domain]
-
-	updated := false
-	if isNewer(rec.A, newRec.A) {
-		rec.A = newRec.A
-		updated = true
-	}
-	if isNewer(rec.AAAA, newRec.AAAA) {
-		rec.AAAA = newRec.AAAA
-		updated = true
-	}
-
-	if updated {
-		s.ips[domain] = rec
+	if s.cache != nil {
+		s.cache.Update(s.name+" "+domain, newRec)
 	}
 	if newRec.A != nil {
-		s.pub.Publish(domain+"4", nil)
+		s.pub.Publish(domain+"4", newRec.A)
 	}
 	if newRec.AAAA != nil {
-		s.pub.Publish(domain+"6", nil)
+		s.pub.Publish(domain+"6", newRec.AAAA)
 	}
-	s.Unlock()
-	common.Must(s.cleanup.Start())
 }
 
 func (s *ClassicNameServer) newReqID() uint16 {
@@ -175,11 +172,11 @@ func (s *ClassicNameServer) newReqID() uint16 {
 
 func (s *ClassicNameServer) addPendingRequest(req *dnsRequest) {
 	s.Lock()
-	defer s.Unlock()
-
 	id := req.msg.ID
 	req.expire = time.Now().Add(time.Second * 8)
 	s.requests[id] = *req
+	s.Unlock()
+	common.Must(s.cleanup.Start())
 }
 
 func (s *ClassicNameServer) sendQuery(ctx context.Context, domain string, option IPOption) {
@@ -190,103 +187,74 @@ func (s *ClassicNameServer) sendQuery(ctx context.Context, domain string, option
 	for _, req := range reqs {
 		s.addPendingRequest(req)
 		b, _ := dns.PackMessage(req.msg)
-		udpCtx := context.Background()
-		if inbound := session.InboundFromContext(ctx); inbound != nil {
-			udpCtx = session.ContextWithInbound(udpCtx, inbound)
-		}
-		udpCtx = session.ContextWithContent(udpCtx, &session.Content{
+		udpCtx := linkContext(ctx, &session.Content{
 			Protocol: "dns",
 		})
-		s.udpServer.Dispatch(udpCtx, s.address, b)
+		s.getUDPServer(ctx).Dispatch(udpCtx, s.address, b)
 	}
 }
 
-func (s *ClassicNameServer) findIPsForDomain(domain string, option IPOption) ([]net.IP, error) {
-	s.RLock()
-	record, found := s.ips[domain]
-	s.RUnlock()
+// QueryRecords implements recordQuerier.
+func (s *ClassicNameServer) QueryRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	newError(s.name, " querying ", qType, ": ", domain).AtDebug().WriteToLog(session.ExportIDToError(ctx))
 
-	if !found {
-		return nil, errRecordNotFound
+	msg, err := buildRecordQuery(domain, qType, s.newReqID(), genEDNS0Options(s.clientIP))
+	if err != nil {
+		return nil, err
 	}
-
-	var ips []net.Address
-	var lastErr error
-	if option.IPv4Enable {
-		a, err := record.A.getIPs()
-		if err != nil {
-			lastErr = err
-		}
-		ips = append(ips, a...)
+	b, err := dns.PackMessage(msg)
+	if err != nil {
+		return nil, newError("failed to pack dns query").Base(err)
 	}
 
-	if option.IPv6Enable {
-		aaaa, err := record.AAAA.getIPs()
-		if err != nil {
-			lastErr = err
-		}
-		ips = append(ips, aaaa...)
-	}
+	id := msg.Header.ID
+	ch := make(chan []byte, 1)
+	s.Lock()
+	s.recordRequests[id] = ch
+	s.Unlock()
+	defer func() {
+		s.Lock()
+		delete(s.recordRequests, id)
+		s.Unlock()
+	}()
 
-	if len(ips) > 0 {
-		return toNetIP(ips), nil
-	}
+	udpCtx := linkContext(ctx, &session.Content{
+		Protocol: "dns",
+	})
+	s.getUDPServer(ctx).Dispatch(udpCtx, s.address, b)
 
-	if lastErr != nil {
-		return nil, lastErr
+	select {
+	case resp := <-ch:
+		return parseRecords(resp)
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
-
-	return nil, dns_feature.ErrEmptyResponse
 }
 
-func (s *ClassicNameServer) QueryIP(ctx context.Context, domain string, option IPOption) ([]net.IP, error) {
+func (s *ClassicNameServer) QueryIP(ctx context.Context, domain string, option IPOption) ([]net.IP, uint32, error) {
 
 	fqdn := Fqdn(domain)
 
-	ips, err := s.findIPsForDomain(fqdn, option)
-	if err != errRecordNotFound {
-		newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
-		return ips, err
+	if s.cache != nil {
+		ips, ttl, refresh, err := s.cache.Lookup(s.name+" "+fqdn, option)
+		if err != errRecordNotFound {
+			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
+			if refresh {
+				newError(s.name, " refreshing stale record of ", domain).AtDebug().WriteToLog()
+				s.sendQuery(ctx, fqdn, option)
+			}
+			return ips, ttl, err
+		}
 	}
 
-	// ipv4 and ipv6 belong to different subscription groups
-	var sub4, sub6 *pubsub.Subscriber
-	if option.IPv4Enable {
-		sub4 = s.pub.Subscribe(fqdn + "4")
+	sub4, sub6 := subscribeRecords(s.pub, fqdn, option)
+	if sub4 != nil {
 		defer sub4.Close()
 	}
-	if option.IPv6Enable {
-		sub6 = s.pub.Subscribe(fqdn + "6")
+	if sub6 != nil {
 		defer sub6.Close()
 	}
-	done := make(chan interface{})
-	go func() {
-		if sub4 != nil {
-			select {
-			case <-sub4.Wait():
-			case <-ctx.Done():
-			}
-		}
-		if sub6 != nil {
-			select {
-			case <-sub6.Wait():
-			case <-ctx.Done():
-			}
-		}
-		close(done)
-	}()
 	s.sendQuery(ctx, fqdn, option)
 
-	for {
-		ips, err := s.findIPsForDomain(fqdn, option)
-		if err != errRecordNotFound {
-			return ips, err
-		}
-
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-done:
-		}
-	}
+	return waitRecords(ctx, sub4, sub6, option)
 }
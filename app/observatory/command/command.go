@@ -0,0 +1,76 @@
+// +build !confonly
+
+package command
+
+//go:generate errorgen
+
+import (
+	"context"
+	"strings"
+
+	grpc "google.golang.org/grpc"
+
+	"v2ray.com/core"
+	"v2ray.com/core/app/observatory"
+	"v2ray.com/core/common"
+	"v2ray.com/core/features/extension"
+)
+
+// observatoryServer is an implementation of ObservatoryService.
+type observatoryServer struct {
+	observatory extension.Observatory
+}
+
+func NewObservatoryServer(o extension.Observatory) ObservatoryServiceServer {
+	return &observatoryServer{
+		observatory: o,
+	}
+}
+
+func (s *observatoryServer) GetOutboundStatus(ctx context.Context, request *GetOutboundStatusRequest) (*GetOutboundStatusResponse, error) {
+	result, err := s.observatory.GetObservation(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status, ok := result.(*observatory.ObservationResult)
+	if !ok {
+		return nil, newError("GetOutboundStatus only works with its own observatory.")
+	}
+
+	if len(request.TagSelector) > 0 {
+		filtered := &observatory.ObservationResult{}
+		for _, s := range status.Status {
+			for _, selector := range request.TagSelector {
+				if strings.HasPrefix(s.OutboundTag, selector) {
+					filtered.Status = append(filtered.Status, s)
+					break
+				}
+			}
+		}
+		status = filtered
+	}
+
+	return &GetOutboundStatusResponse{
+		Status: status,
+	}, nil
+}
+
+type service struct {
+	observatory extension.Observatory
+}
+
+func (s *service) Register(server *grpc.Server) {
+	RegisterObservatoryServiceServer(server, NewObservatoryServer(s.observatory))
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
+		s := new(service)
+
+		core.RequireFeatures(ctx, func(o extension.Observatory) {
+			s.observatory = o
+		})
+
+		return s, nil
+	}))
+}
@@ -0,0 +1,256 @@
+// +build !confonly
+
+package observatory
+
+//go:generate errorgen
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"v2ray.com/core"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/common/task"
+	"v2ray.com/core/features/extension"
+	"v2ray.com/core/features/outbound"
+	"v2ray.com/core/transport"
+	"v2ray.com/core/transport/pipe"
+)
+
+const (
+	defaultProbeURL      = "https://www.google.com/generate_204"
+	defaultProbeInterval = time.Minute
+	defaultHistorySize   = 10
+	maxProbeTimeout      = time.Second * 10
+)
+
+// Observer is an implementation of extension.Observatory.
+// It periodically sends a request through each selected outbound handler, and records whether the request succeeds.
+type Observer struct {
+	ctx    context.Context
+	config *Config
+	ohm    outbound.Manager
+
+	access sync.RWMutex
+	status map[string]*OutboundStatus
+
+	checker *task.Periodic
+}
+
+// New creates a new Observer based on the given config.
+func New(ctx context.Context, config *Config) (*Observer, error) {
+	o := &Observer{
+		ctx:    ctx,
+		config: config,
+		status: make(map[string]*OutboundStatus),
+	}
+	if err := core.RequireFeatures(ctx, func(om outbound.Manager) {
+		o.ohm = om
+	}); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Type implements common.HasType.
+func (*Observer) Type() interface{} {
+	return extension.ObservatoryType()
+}
+
+// Start implements common.Runnable.
+func (o *Observer) Start() error {
+	if len(o.config.SubjectSelector) == 0 {
+		return nil
+	}
+	if _, ok := o.ohm.(outbound.HandlerSelector); !ok {
+		return newError("outbound.Manager is not a HandlerSelector")
+	}
+	o.checker = &task.Periodic{
+		Interval: o.probeInterval(),
+		Execute:  o.probeAll,
+	}
+	// The first round of probes runs immediately, and it shouldn't block the startup of V2Ray.
+	go o.checker.Start() // nolint: errcheck
+	return nil
+}
+
+// Close implements common.Closable.
+func (o *Observer) Close() error {
+	if o.checker != nil {
+		return o.checker.Close()
+	}
+	return nil
+}
+
+// GetObservation implements extension.Observatory. The result is an *ObservationResult, sorted by outbound tag.
+func (o *Observer) GetObservation(ctx context.Context) (proto.Message, error) {
+	o.access.RLock()
+	defer o.access.RUnlock()
+
+	result := &ObservationResult{
+		Status: make([]*OutboundStatus, 0, len(o.status)),
+	}
+	for _, s := range o.status {
+		result.Status = append(result.Status, proto.Clone(s).(*OutboundStatus))
+	}
+	sort.Slice(result.Status, func(i, j int) bool {
+		return result.Status[i].OutboundTag < result.Status[j].OutboundTag
+	})
+	return result, nil
+}
+
+func (o *Observer) probeURL() string {
+	if len(o.config.ProbeUrl) > 0 {
+		return o.config.ProbeUrl
+	}
+	return defaultProbeURL
+}
+
+func (o *Observer) probeInterval() time.Duration {
+	if o.config.ProbeInterval > 0 {
+		return time.Duration(o.config.ProbeInterval) * time.Second
+	}
+	return defaultProbeInterval
+}
+
+func (o *Observer) probeTimeout() time.Duration {
+	if interval := o.probeInterval(); interval < maxProbeTimeout {
+		return interval
+	}
+	return maxProbeTimeout
+}
+
+func (o *Observer) historySize() int {
+	if o.config.HistorySize > 0 {
+		return int(o.config.HistorySize)
+	}
+	return defaultHistorySize
+}
+
+func (o *Observer) probeAll() error {
+	hs, ok := o.ohm.(outbound.HandlerSelector)
+	if !ok {
+		return newError("outbound.Manager is not a HandlerSelector")
+	}
+	tags := hs.Select(o.config.SubjectSelector)
+	o.removeStale(tags)
+
+	var wg sync.WaitGroup
+	for _, tag := range tags {
+		wg.Add(1)
+		go func(tag string) {
+			defer wg.Done()
+			o.record(tag, o.probe(tag))
+		}(tag)
+	}
+	wg.Wait()
+	return nil
+}
+
+// probe sends a request to the probe URL through the outbound handler of the given tag.
+func (o *Observer) probe(tag string) *ProbeResult {
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(_ context.Context, network, addr string) (net.Conn, error) {
+				return o.dial(tag, network, addr)
+			},
+			DisableKeepAlives: true,
+		},
+		Timeout: o.probeTimeout(),
+	}
+
+	start := time.Now()
+	result := &ProbeResult{
+		Time: start.Unix(),
+	}
+	resp, err := client.Get(o.probeURL())
+	if err != nil {
+		newError("failed to probe outbound ", tag).Base(err).AtDebug().WriteToLog()
+		result.ErrorReason = err.Error()
+		return result
+	}
+	common.Close(resp.Body) // nolint: errcheck
+	result.Alive = true
+	result.Delay = time.Since(start).Milliseconds()
+	return result
+}
+
+// dial opens a connection to the given address through the outbound handler of the given tag.
+func (o *Observer) dial(tag string, network string, addr string) (net.Conn, error) {
+	handler := o.ohm.GetHandler(tag)
+	if handler == nil {
+		return nil, newError("outbound handler ", tag, " not found")
+	}
+	dest, err := net.ParseDestination(network + ":" + addr)
+	if err != nil {
+		return nil, newError("invalid probe destination ", addr).Base(err)
+	}
+
+	// The connection outlives the dialing context of http.Transport, so it must not be derived from it.
+	ctx := session.ContextWithOutbound(o.ctx, &session.Outbound{
+		Target: dest,
+	})
+	uplinkReader, uplinkWriter := pipe.New(pipe.WithoutSizeLimit())
+	downlinkReader, downlinkWriter := pipe.New(pipe.WithoutSizeLimit())
+
+	go handler.Dispatch(ctx, &transport.Link{Reader: uplinkReader, Writer: downlinkWriter})
+	return net.NewConnection(net.ConnectionInputMulti(uplinkWriter), net.ConnectionOutputMulti(downlinkReader)), nil
+}
+
+func (o *Observer) record(tag string, result *ProbeResult) {
+	o.access.Lock()
+	defer o.access.Unlock()
+
+	s, found := o.status[tag]
+	if !found {
+		s = &OutboundStatus{
+			OutboundTag: tag,
+		}
+		o.status[tag] = s
+	}
+
+	s.Alive = result.Alive
+	s.LastTryTime = result.Time
+	if result.Alive {
+		s.Delay = result.Delay
+		s.LastSeenTime = result.Time
+		s.LastErrorReason = ""
+	} else {
+		s.LastErrorReason = result.ErrorReason
+	}
+
+	s.History = append(s.History, result)
+	if n := len(s.History) - o.historySize(); n > 0 {
+		s.History = s.History[n:]
+	}
+}
+
+// removeStale removes status of outbounds that are no longer selected, e.g., removed through API.
+func (o *Observer) removeStale(tags []string) {
+	selected := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		selected[tag] = true
+	}
+
+	o.access.Lock()
+	defer o.access.Unlock()
+
+	for tag := range o.status {
+		if !selected[tag] {
+			delete(o.status, tag)
+		}
+	}
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return New(ctx, config.(*Config))
+	}))
+}
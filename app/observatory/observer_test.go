@@ -0,0 +1,116 @@
+package observatory_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"v2ray.com/core"
+	. "v2ray.com/core/app/observatory"
+	"v2ray.com/core/app/proxyman"
+	_ "v2ray.com/core/app/proxyman/outbound"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/serial"
+	"v2ray.com/core/features/extension"
+	"v2ray.com/core/features/outbound"
+	"v2ray.com/core/proxy/blackhole"
+	"v2ray.com/core/proxy/freedom"
+	"v2ray.com/core/testing/mocks"
+	v2httptest "v2ray.com/core/testing/servers/http"
+	"v2ray.com/core/testing/servers/tcp"
+	_ "v2ray.com/core/transport/internet/tcp"
+)
+
+func TestObserverProbe(t *testing.T) {
+	httpServer := &v2httptest.Server{
+		Port: tcp.PickPort(),
+		PathHandler: map[string]http.HandlerFunc{
+			"/generate_204": func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+		},
+	}
+	dest, err := httpServer.Start()
+	common.Must(err)
+	defer httpServer.Close()
+
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&Config{
+				SubjectSelector: []string{"proxy-"},
+				ProbeUrl:        "http://" + dest.NetAddr() + "/generate_204",
+				ProbeInterval:   1,
+				HistorySize:     2,
+			}),
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				Tag:           "proxy-alive",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+			{
+				Tag:           "proxy-dead",
+				ProxySettings: serial.ToTypedMessage(&blackhole.Config{}),
+			},
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+	common.Must(v.Start())
+	defer v.Close()
+
+	observatory := v.GetFeature(extension.ObservatoryType()).(extension.Observatory)
+
+	var status []*OutboundStatus
+	for i := 0; i < 50; i++ {
+		time.Sleep(time.Millisecond * 100)
+		result, err := observatory.GetObservation(context.Background())
+		common.Must(err)
+		status = result.(*ObservationResult).Status
+		if len(status) == 2 && len(status[0].History) == 2 && len(status[1].History) == 2 {
+			break
+		}
+	}
+
+	if len(status) != 2 {
+		t.Fatal("expect 2 observed outbounds, but got ", len(status))
+	}
+	if status[0].OutboundTag != "proxy-alive" || !status[0].Alive || status[0].LastSeenTime == 0 {
+		t.Error("unexpected status of proxy-alive: ", status[0])
+	}
+	if status[1].OutboundTag != "proxy-dead" || status[1].Alive || status[1].LastErrorReason == "" || status[1].LastSeenTime != 0 {
+		t.Error("unexpected status of proxy-dead: ", status[1])
+	}
+	if len(status[0].History) != 2 {
+		t.Error("expect 2 probe results in history, but got ", len(status[0].History))
+	}
+}
+
+func TestObserverStartWithoutHandlerSelector(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	v, err := core.New(&core.Config{})
+	common.Must(err)
+	// The mock outbound manager doesn't select handlers.
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockOhm.EXPECT().Type().Return(outbound.ManagerType()).AnyTimes()
+	common.Must(v.AddFeature(mockOhm))
+
+	o, err := core.CreateObject(v, &Config{
+		SubjectSelector: []string{"proxy-"},
+	})
+	common.Must(err)
+	if err := o.(*Observer).Start(); err == nil {
+		t.Error("expect observer to fail to start without a handler selector")
+	}
+}
@@ -58,6 +58,37 @@ func (p *Policy) overrideWith(another *Policy) {
 			Connection: another.Buffer.Connection,
 		}
 	}
+	if another.RateLimit != nil {
+		p.RateLimit = &Policy_RateLimit{
+			Uplink:        another.RateLimit.Uplink,
+			Downlink:      another.RateLimit.Downlink,
+			UplinkBurst:   another.RateLimit.UplinkBurst,
+			DownlinkBurst: another.RateLimit.DownlinkBurst,
+		}
+	}
+	if another.UserLimit != nil {
+		p.UserLimit = &Policy_UserLimit{
+			MaxConnections: another.UserLimit.MaxConnections,
+			MaxSourceIps:   another.UserLimit.MaxSourceIps,
+		}
+	}
+}
+
+// ToCorePolicy converts this RateLimit to policy.RateLimit.
+func (r *Policy_RateLimit) ToCorePolicy() policy.RateLimit {
+	rl := policy.RateLimit{
+		Uplink:        r.GetUplink(),
+		Downlink:      r.GetDownlink(),
+		UplinkBurst:   r.GetUplinkBurst(),
+		DownlinkBurst: r.GetDownlinkBurst(),
+	}
+	if rl.UplinkBurst == 0 {
+		rl.UplinkBurst = rl.Uplink
+	}
+	if rl.DownlinkBurst == 0 {
+		rl.DownlinkBurst = rl.Downlink
+	}
+	return rl
 }
 
 // ToCorePolicy converts this Policy to policy.Session.
@@ -73,21 +104,36 @@ func (p *Policy) ToCorePolicy() policy.Session {
 	if p.Stats != nil {
 		cp.Stats.UserUplink = p.Stats.UserUplink
 		cp.Stats.UserDownlink = p.Stats.UserDownlink
+		cp.Stats.UserOnline = p.Stats.UserOnline
 	}
 	if p.Buffer != nil {
 		cp.Buffer.PerConnection = p.Buffer.Connection
 	}
+	if p.RateLimit != nil {
+		cp.RateLimit = p.RateLimit.ToCorePolicy()
+	}
+	if p.UserLimit != nil {
+		cp.UserLimit.MaxConnections = p.UserLimit.MaxConnections
+		cp.UserLimit.MaxSourceIPs = p.UserLimit.MaxSourceIps
+	}
 	return cp
 }
 
 // ToCorePolicy converts this SystemPolicy to policy.System.
 func (p *SystemPolicy) ToCorePolicy() policy.System {
-	return policy.System{
+	sp := policy.System{
 		Stats: policy.SystemStats{
-			InboundUplink:    p.Stats.InboundUplink,
-			InboundDownlink:  p.Stats.InboundDownlink,
-			OutboundUplink:   p.Stats.OutboundUplink,
-			OutboundDownlink: p.Stats.OutboundDownlink,
+			InboundUplink:    p.Stats.GetInboundUplink(),
+			InboundDownlink:  p.Stats.GetInboundDownlink(),
+			OutboundUplink:   p.Stats.GetOutboundUplink(),
+			OutboundDownlink: p.Stats.GetOutboundDownlink(),
 		},
 	}
+	if len(p.InboundRateLimit) > 0 {
+		sp.InboundRateLimit = make(map[string]policy.RateLimit, len(p.InboundRateLimit))
+		for tag, rl := range p.InboundRateLimit {
+			sp.InboundRateLimit[tag] = rl.ToCorePolicy()
+		}
+	}
+	return sp
 }
@@ -19,6 +19,18 @@ func TestPolicy(t *testing.T) {
 						Value: 2,
 					},
 				},
+				RateLimit: &Policy_RateLimit{
+					Uplink:        1024,
+					Downlink:      2048,
+					DownlinkBurst: 4096,
+				},
+			},
+		},
+		System: &SystemPolicy{
+			InboundRateLimit: map[string]*Policy_RateLimit{
+				"socks": {
+					Uplink: 8192,
+				},
 			},
 		},
 	})
@@ -34,6 +46,15 @@ func TestPolicy(t *testing.T) {
 		if p.Timeouts.ConnectionIdle != pDefault.Timeouts.ConnectionIdle {
 			t.Error("expect ", pDefault.Timeouts.ConnectionIdle, " sec timeout, but got ", p.Timeouts.ConnectionIdle)
 		}
+		expected := policy.RateLimit{
+			Uplink:        1024,
+			Downlink:      2048,
+			UplinkBurst:   1024,
+			DownlinkBurst: 4096,
+		}
+		if p.RateLimit != expected {
+			t.Error("expect rate limit ", expected, ", but got ", p.RateLimit)
+		}
 	}
 
 	{
@@ -41,5 +62,15 @@ func TestPolicy(t *testing.T) {
 		if p.Timeouts.Handshake != pDefault.Timeouts.Handshake {
 			t.Error("expect ", pDefault.Timeouts.Handshake, " sec timeout, but got ", p.Timeouts.Handshake)
 		}
+		if p.RateLimit.Uplink != 0 || p.RateLimit.Downlink != 0 {
+			t.Error("expect no rate limit, but got ", p.RateLimit)
+		}
+	}
+
+	{
+		p := manager.ForSystem()
+		if rl := p.InboundRateLimit["socks"]; rl.Uplink != 8192 || rl.UplinkBurst != 8192 || rl.Downlink != 0 {
+			t.Error("unexpected inbound rate limit: ", p.InboundRateLimit)
+		}
 	}
 }
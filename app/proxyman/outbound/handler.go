@@ -2,10 +2,14 @@ package outbound
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"v2ray.com/core"
 	"v2ray.com/core/app/proxyman"
 	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
 	"v2ray.com/core/common/mux"
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/session"
@@ -44,8 +48,24 @@ func getStatCounter(v *core.Instance, tag string) (stats.Counter, stats.Counter)
 	return uplinkCounter, downlinkCounter
 }
 
+// dialLatencyBuckets are the bucket bounds of dial latency histograms, in seconds.
+var dialLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+func getConnectionStats(v *core.Instance, tag string) (stats.Gauge, stats.Histogram) {
+	statsManager, ok := v.GetFeature(stats.ManagerType()).(stats.Manager)
+	if len(tag) == 0 || !ok {
+		return nil, nil
+	}
+	gauge, _ := stats.GetOrRegisterGauge(statsManager, "outbound>>>"+tag+">>>connections")
+	histogram, _ := stats.GetOrRegisterHistogram(statsManager, "outbound>>>"+tag+">>>dial_latency_seconds", dialLatencyBuckets)
+	return gauge, histogram
+}
+
 // Handler is an implements of outbound.Handler.
 type Handler struct {
+	// activeConnections is accessed atomically, and must be 64-bit aligned.
+	activeConnections int64
+
 	tag             string
 	senderSettings  *proxyman.SenderConfig
 	streamSettings  *internet.MemoryStreamConfig
@@ -54,17 +74,22 @@ type Handler struct {
 	mux             *mux.ClientManager
 	uplinkCounter   stats.Counter
 	downlinkCounter stats.Counter
+	connectionGauge stats.Gauge
+	dialLatency     stats.Histogram
 }
 
 // NewHandler create a new Handler based on the given configuration.
 func NewHandler(ctx context.Context, config *core.OutboundHandlerConfig) (outbound.Handler, error) {
 	v := core.MustFromContext(ctx)
 	uplinkCounter, downlinkCounter := getStatCounter(v, config.Tag)
+	connectionGauge, dialLatency := getConnectionStats(v, config.Tag)
 	h := &Handler{
 		tag:             config.Tag,
 		outboundManager: v.GetFeature(outbound.ManagerType()).(outbound.Manager),
 		uplinkCounter:   uplinkCounter,
 		downlinkCounter: downlinkCounter,
+		connectionGauge: connectionGauge,
+		dialLatency:     dialLatency,
 	}
 
 	if config.SenderSettings != nil {
@@ -131,6 +156,23 @@ func (h *Handler) Tag() string {
 
 // Dispatch implements proxy.Outbound.Dispatch.
 func (h *Handler) Dispatch(ctx context.Context, link *transport.Link) {
+	atomic.AddInt64(&h.activeConnections, 1)
+	if h.connectionGauge != nil {
+		h.connectionGauge.Add(1)
+	}
+	link = &transport.Link{
+		Reader: link.Reader,
+		Writer: &trackedWriter{
+			Writer: link.Writer,
+			release: func() {
+				atomic.AddInt64(&h.activeConnections, -1)
+				if h.connectionGauge != nil {
+					h.connectionGauge.Add(-1)
+				}
+			},
+		},
+	}
+
 	if h.mux != nil && (h.mux.Enabled || session.MuxPreferedFromContext(ctx)) {
 		if err := h.mux.Dispatch(ctx, link); err != nil {
 			newError("failed to process mux outbound traffic").Base(err).WriteToLog(session.ExportIDToError(ctx))
@@ -148,6 +190,29 @@ func (h *Handler) Dispatch(ctx context.Context, link *transport.Link) {
 	}
 }
 
+// ActiveConnections implements outbound.LoadReporter.
+func (h *Handler) ActiveConnections() int64 {
+	return atomic.LoadInt64(&h.activeConnections)
+}
+
+// trackedWriter calls release once, when the connection is closed or interrupted.
+// A mux session may still be alive after Dispatch returns, so the writer is the only reliable place to track it.
+type trackedWriter struct {
+	buf.Writer
+	once    sync.Once
+	release func()
+}
+
+func (w *trackedWriter) Close() error {
+	w.once.Do(w.release)
+	return common.Close(w.Writer)
+}
+
+func (w *trackedWriter) Interrupt() {
+	w.once.Do(w.release)
+	common.Interrupt(w.Writer)
+}
+
 // Address implements internet.Dialer.
 func (h *Handler) Address() net.Address {
 	if h.senderSettings == nil || h.senderSettings.Via == nil {
@@ -196,7 +261,11 @@ func (h *Handler) Dial(ctx context.Context, dest net.Destination) (internet.Conn
 		}
 	}
 
+	start := time.Now()
 	conn, err := internet.Dial(ctx, dest, h.streamSettings)
+	if err == nil && h.dialLatency != nil {
+		h.dialLatency.Observe(time.Since(start).Seconds())
+	}
 	return h.getStatCouterConnection(conn), err
 }
 
@@ -3,7 +3,15 @@
 package router
 
 import (
+	"context"
+	"sort"
+	"sync/atomic"
+
+	"v2ray.com/core"
+	"v2ray.com/core/app/observatory"
 	"v2ray.com/core/common/dice"
+	"v2ray.com/core/common/errors"
+	"v2ray.com/core/features/extension"
 	"v2ray.com/core/features/outbound"
 )
 
@@ -23,24 +31,200 @@ func (s *RandomStrategy) PickOutbound(tags []string) string {
 	return tags[dice.Roll(n)]
 }
 
+// RoundRobinStrategy picks outbounds in the order of their tags, one after another.
+type RoundRobinStrategy struct {
+	index uint32
+}
+
+func (s *RoundRobinStrategy) PickOutbound(tags []string) string {
+	n := len(tags)
+	if n == 0 {
+		panic("0 tags")
+	}
+
+	sorted := make([]string, n)
+	copy(sorted, tags)
+	sort.Strings(sorted)
+
+	i := atomic.AddUint32(&s.index, 1) - 1
+	return sorted[i%uint32(n)]
+}
+
+// LeastPingStrategy picks the outbound with the lowest delay, according to the observatory.
+type LeastPingStrategy struct {
+	config  *LeastPingConfig
+	observe func() *observatory.ObservationResult
+}
+
+func (s *LeastPingStrategy) PickOutbound(tags []string) string {
+	n := len(tags)
+	if n == 0 {
+		panic("0 tags")
+	}
+
+	delays := make(map[string]int64)
+	if observation := s.observe(); observation != nil {
+		for _, status := range observation.Status {
+			if status.Alive {
+				delays[status.OutboundTag] = status.Delay
+			}
+		}
+	}
+
+	var candidates []string
+	var best int64 = -1
+	for _, tag := range tags {
+		delay, found := delays[tag]
+		if !found {
+			continue
+		}
+		candidates = append(candidates, tag)
+		if best < 0 || delay < best {
+			best = delay
+		}
+	}
+
+	if len(candidates) == 0 {
+		// None of the outbounds has been measured yet.
+		return tags[dice.Roll(n)]
+	}
+
+	limit := best + int64(s.config.GetTolerance())
+	if baseline := int64(s.config.GetBaseline()); baseline > limit {
+		limit = baseline
+	}
+
+	picked := candidates[:0]
+	for _, tag := range candidates {
+		if delays[tag] <= limit {
+			picked = append(picked, tag)
+		}
+	}
+	return picked[dice.Roll(len(picked))]
+}
+
+// LeastLoadStrategy picks the outbound with the fewest active connections.
+type LeastLoadStrategy struct {
+	ohm outbound.Manager
+}
+
+func (s *LeastLoadStrategy) PickOutbound(tags []string) string {
+	n := len(tags)
+	if n == 0 {
+		panic("0 tags")
+	}
+
+	var candidates []string
+	var least int64 = -1
+	for _, tag := range tags {
+		var load int64
+		if reporter, ok := s.ohm.GetHandler(tag).(outbound.LoadReporter); ok {
+			load = reporter.ActiveConnections()
+		}
+		switch {
+		case least < 0 || load < least:
+			least = load
+			candidates = append(candidates[:0], tag)
+		case load == least:
+			candidates = append(candidates, tag)
+		}
+	}
+	return candidates[dice.Roll(len(candidates))]
+}
+
 type Balancer struct {
-	selectors []string
-	strategy  BalancingStrategy
-	ohm       outbound.Manager
+	selectors   []string
+	strategy    BalancingStrategy
+	ohm         outbound.Manager
+	ctx         context.Context
+	fallbackTag string
 }
 
 func (b *Balancer) PickOutbound() (string, error) {
-	hs, ok := b.ohm.(outbound.HandlerSelector)
-	if !ok {
-		return "", newError("outbound.Manager is not a HandlerSelector")
+	tags, err := b.candidates()
+	if err != nil {
+		return "", err
 	}
-	tags := hs.Select(b.selectors)
 	if len(tags) == 0 {
-		return "", newError("no available outbounds selected")
+		return b.fallback(newError("no available outbounds selected"))
 	}
 	tag := b.strategy.PickOutbound(tags)
 	if tag == "" {
-		return "", newError("balancing strategy returns empty tag")
+		return b.fallback(newError("balancing strategy returns empty tag"))
 	}
 	return tag, nil
 }
+
+// candidates returns the healthy outbounds that the balancer picks from. Unlike PickOutbound, it doesn't change
+// the state of the balancing strategy.
+func (b *Balancer) candidates() ([]string, error) {
+	hs, ok := b.ohm.(outbound.HandlerSelector)
+	if !ok {
+		return nil, newError("outbound.Manager is not a HandlerSelector")
+	}
+	return b.filterUnhealthy(hs.Select(b.selectors)), nil
+}
+
+// fallback returns the fallback tag if there is one, or the given error otherwise.
+func (b *Balancer) fallback(err *errors.Error) (string, error) {
+	if len(b.fallbackTag) == 0 {
+		return "", err
+	}
+	newError("fallback to [", b.fallbackTag, "]").Base(err).AtInfo().WriteToLog()
+	return b.fallbackTag, nil
+}
+
+// observatory returns the Observatory of current V2Ray instance, or nil if there is none.
+// It is looked up on demand, as the Observatory may be registered after the Router.
+func (b *Balancer) observatory() extension.Observatory {
+	if b.ctx == nil {
+		return nil
+	}
+	v := core.FromContext(b.ctx)
+	if v == nil {
+		return nil
+	}
+	o, _ := v.GetFeature(extension.ObservatoryType()).(extension.Observatory)
+	return o
+}
+
+// observation returns the latest observation result, or nil if it is not available.
+func (b *Balancer) observation() *observatory.ObservationResult {
+	o := b.observatory()
+	if o == nil {
+		return nil
+	}
+	result, err := o.GetObservation(b.ctx)
+	if err != nil {
+		newError("failed to get observation").Base(err).AtWarning().WriteToLog()
+		return nil
+	}
+	observation, _ := result.(*observatory.ObservationResult)
+	return observation
+}
+
+// filterUnhealthy removes the outbounds that failed their last probe. Outbounds that are not observed are kept.
+func (b *Balancer) filterUnhealthy(tags []string) []string {
+	observation := b.observation()
+	if observation == nil {
+		return tags
+	}
+
+	dead := make(map[string]bool)
+	for _, s := range observation.Status {
+		if !s.Alive {
+			dead[s.OutboundTag] = true
+		}
+	}
+	if len(dead) == 0 {
+		return tags
+	}
+
+	alive := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !dead[tag] {
+			alive = append(alive, tag)
+		}
+	}
+	return alive
+}
@@ -0,0 +1,181 @@
+package router_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"v2ray.com/core"
+	"v2ray.com/core/app/observatory"
+	. "v2ray.com/core/app/router"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/features/outbound"
+	routing_session "v2ray.com/core/features/routing/session"
+	"v2ray.com/core/testing/mocks"
+	"v2ray.com/core/transport"
+)
+
+type loadedHandler struct {
+	tag  string
+	load int64
+}
+
+func (h *loadedHandler) Tag() string {
+	return h.tag
+}
+
+func (*loadedHandler) Dispatch(ctx context.Context, link *transport.Link) {}
+
+func (*loadedHandler) Start() error {
+	return nil
+}
+
+func (*loadedHandler) Close() error {
+	return nil
+}
+
+func (h *loadedHandler) ActiveConnections() int64 {
+	return h.load
+}
+
+func TestRoundRobinStrategy(t *testing.T) {
+	s := &RoundRobinStrategy{}
+	tags := []string{"c", "a", "b"}
+	for i, expected := range []string{"a", "b", "c", "a", "b"} {
+		if tag := s.PickOutbound(tags); tag != expected {
+			t.Error("pick ", i, ": expect ", expected, ", but actually ", tag)
+		}
+	}
+}
+
+func newBalancerRouter(ctx context.Context, ctrl *gomock.Controller, rule *BalancingRule, ohm outbound.Manager) *Router {
+	config := &Config{
+		Rule: []*RoutingRule{
+			{
+				TargetTag: &RoutingRule_BalancingTag{
+					BalancingTag: rule.Tag,
+				},
+				Networks: []net.Network{net.Network_TCP},
+			},
+		},
+		BalancingRule: []*BalancingRule{rule},
+	}
+
+	mockHs := mocks.NewOutboundHandlerSelector(ctrl)
+	mockHs.EXPECT().Select(gomock.Eq(rule.OutboundSelector)).Return([]string{"test-1", "test-2", "test-3"}).AnyTimes()
+
+	r := new(Router)
+	common.Must(r.Init(ctx, config, mocks.NewDNSClient(ctrl), &mockOutboundManager{
+		Manager:         ohm,
+		HandlerSelector: mockHs,
+	}))
+	return r
+}
+
+func TestLeastPingStrategy(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	v, err := core.New(&core.Config{})
+	common.Must(err)
+	common.Must(v.AddFeature(&mockObservatory{
+		result: &observatory.ObservationResult{
+			Status: []*observatory.OutboundStatus{
+				{OutboundTag: "test-1", Alive: true, Delay: 100},
+				{OutboundTag: "test-2", Alive: true, Delay: 130},
+				{OutboundTag: "test-3", Alive: true, Delay: 300},
+			},
+		},
+	}))
+
+	r := newBalancerRouter(context.WithValue(context.Background(), v2rayKey, v), mockCtl, &BalancingRule{
+		Tag:              "balance",
+		OutboundSelector: []string{"test-"},
+		Strategy:         BalancingRule_LeastPing,
+		LeastPing: &LeastPingConfig{
+			Tolerance: 50,
+		},
+	}, mocks.NewOutboundManager(mockCtl))
+
+	ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("v2ray.com"), 80)})
+	picked := make(map[string]bool)
+	for i := 0; i < 64; i++ {
+		route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+		common.Must(err)
+		tag := route.GetOutboundTag()
+		picked[tag] = true
+	}
+	if picked["test-3"] {
+		t.Error("expect 'test-3' not picked as it is out of tolerance")
+	}
+	if !picked["test-1"] || !picked["test-2"] {
+		t.Error("expect both 'test-1' and 'test-2' picked, but actually ", picked)
+	}
+}
+
+func TestLeastLoadStrategy(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockOhm.EXPECT().GetHandler("test-1").Return(&loadedHandler{tag: "test-1", load: 5}).AnyTimes()
+	mockOhm.EXPECT().GetHandler("test-2").Return(&loadedHandler{tag: "test-2", load: 2}).AnyTimes()
+	mockOhm.EXPECT().GetHandler("test-3").Return(&loadedHandler{tag: "test-3", load: 7}).AnyTimes()
+
+	r := newBalancerRouter(context.Background(), mockCtl, &BalancingRule{
+		Tag:              "balance",
+		OutboundSelector: []string{"test-"},
+		Strategy:         BalancingRule_LeastLoad,
+	}, mockOhm)
+
+	ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("v2ray.com"), 80)})
+	for i := 0; i < 8; i++ {
+		route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+		common.Must(err)
+		tag := route.GetOutboundTag()
+		if tag != "test-2" {
+			t.Error("expect tag 'test-2', but actually ", tag)
+		}
+	}
+}
+
+func TestBalancerFallback(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	v, err := core.New(&core.Config{})
+	common.Must(err)
+	common.Must(v.AddFeature(&mockObservatory{
+		result: &observatory.ObservationResult{
+			Status: []*observatory.OutboundStatus{
+				{OutboundTag: "test-1", Alive: false},
+				{OutboundTag: "test-2", Alive: false},
+				{OutboundTag: "test-3", Alive: false},
+			},
+		},
+	}))
+	vctx := context.WithValue(context.Background(), v2rayKey, v)
+	ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("v2ray.com"), 80)})
+
+	r := newBalancerRouter(vctx, mockCtl, &BalancingRule{
+		Tag:              "balance",
+		OutboundSelector: []string{"test-"},
+	}, mocks.NewOutboundManager(mockCtl))
+	if _, err := r.PickRoute(routing_session.AsRoutingContext(ctx)); err == nil {
+		t.Error("expect error when all outbounds are down and there is no fallback")
+	}
+
+	r = newBalancerRouter(vctx, mockCtl, &BalancingRule{
+		Tag:              "balance",
+		OutboundSelector: []string{"test-"},
+		FallbackTag:      "direct",
+	}, mocks.NewOutboundManager(mockCtl))
+	route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+	common.Must(err)
+	tag := route.GetOutboundTag()
+	if tag != "direct" {
+		t.Error("expect tag 'direct', but actually ", tag)
+	}
+}
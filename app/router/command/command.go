@@ -0,0 +1,167 @@
+// +build !confonly
+
+package command
+
+//go:generate errorgen
+
+import (
+	"context"
+
+	grpc "google.golang.org/grpc"
+
+	"v2ray.com/core"
+	"v2ray.com/core/app/router"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/features/routing"
+	routing_session "v2ray.com/core/features/routing/session"
+)
+
+// routingServer is an implementation of RoutingService.
+type routingServer struct {
+	router routing.Router
+}
+
+func NewRoutingServer(r routing.Router) RoutingServiceServer {
+	return &routingServer{
+		router: r,
+	}
+}
+
+func (s *routingServer) getRouter() (*router.Router, error) {
+	r, ok := s.router.(*router.Router)
+	if !ok {
+		return nil, newError("RoutingService only works with its own router.")
+	}
+	return r, nil
+}
+
+func (s *routingServer) AddRule(ctx context.Context, request *AddRuleRequest) (*AddRuleResponse, error) {
+	r, err := s.getRouter()
+	if err != nil {
+		return nil, err
+	}
+	if request.Rule == nil {
+		return nil, newError("rule is not specified")
+	}
+	if err := r.AddRule(request.Rule, request.Prepend); err != nil {
+		return nil, err
+	}
+	return &AddRuleResponse{}, nil
+}
+
+func (s *routingServer) RemoveRule(ctx context.Context, request *RemoveRuleRequest) (*RemoveRuleResponse, error) {
+	r, err := s.getRouter()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.RemoveRule(request.RuleTag); err != nil {
+		return nil, err
+	}
+	return &RemoveRuleResponse{}, nil
+}
+
+func (s *routingServer) ListRules(ctx context.Context, request *ListRulesRequest) (*ListRulesResponse, error) {
+	r, err := s.getRouter()
+	if err != nil {
+		return nil, err
+	}
+	return &ListRulesResponse{
+		Rule: r.ListRules(),
+	}, nil
+}
+
+func (s *routingServer) ReplaceRules(ctx context.Context, request *ReplaceRulesRequest) (*ReplaceRulesResponse, error) {
+	r, err := s.getRouter()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.ReplaceRules(request.Rule); err != nil {
+		return nil, err
+	}
+	return &ReplaceRulesResponse{}, nil
+}
+
+func (s *routingServer) TestRoute(ctx context.Context, request *TestRouteRequest) (*TestRouteResponse, error) {
+	r, err := s.getRouter()
+	if err != nil {
+		return nil, err
+	}
+	if request.TargetAddress == nil {
+		return nil, newError("target address is not specified")
+	}
+	network := request.Network
+	if network == net.Network_Unknown {
+		network = net.Network_TCP
+	}
+
+	inbound := &session.Inbound{
+		Tag: request.InboundTag,
+	}
+	if request.SourceAddress != nil {
+		inbound.Source = net.Destination{
+			Network: network,
+			Address: request.SourceAddress.AsAddress(),
+			Port:    net.Port(request.SourcePort),
+		}
+	}
+	if len(request.UserEmail) > 0 {
+		inbound.User = &protocol.MemoryUser{
+			Email: request.UserEmail,
+		}
+	}
+	content := &session.Content{
+		Protocol: request.Protocol,
+	}
+	for k, v := range request.Attributes {
+		content.SetAttribute(k, v)
+	}
+
+	routeCtx := &routing_session.Context{
+		Inbound: inbound,
+		Outbound: &session.Outbound{
+			Target: net.Destination{
+				Network: network,
+				Address: request.TargetAddress.AsAddress(),
+				Port:    net.Port(request.TargetPort),
+			},
+		},
+		Content: content,
+	}
+
+	result, err := r.TestRoute(routeCtx)
+	if err != nil {
+		return nil, err
+	}
+	return &TestRouteResponse{
+		Matched:            result.RuleIndex >= 0,
+		RuleIndex:          int32(result.RuleIndex),
+		RuleTag:            result.RuleTag,
+		OutboundTag:        result.OutboundTag,
+		BalancerTag:        result.BalancerTag,
+		BalancerCandidates: result.BalancerCandidates,
+		DomainResolved:     result.DomainResolved,
+	}, nil
+}
+
+type service struct {
+	router routing.Router
+}
+
+func (s *service) Register(server *grpc.Server) {
+	RegisterRoutingServiceServer(server, NewRoutingServer(s.router))
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
+		s := new(service)
+
+		core.RequireFeatures(ctx, func(r routing.Router) {
+			s.router = r
+		})
+
+		return s, nil
+	}))
+}
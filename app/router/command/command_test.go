@@ -0,0 +1,193 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"v2ray.com/core/app/router"
+	. "v2ray.com/core/app/router/command"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/session"
+	routing_session "v2ray.com/core/features/routing/session"
+	"v2ray.com/core/testing/mocks"
+)
+
+func domainRule(ruleTag string, domain string, outboundTag string) *router.RoutingRule {
+	return &router.RoutingRule{
+		RuleTag: ruleTag,
+		Domain: []*router.Domain{
+			{
+				Type:  router.Domain_Domain,
+				Value: domain,
+			},
+		},
+		TargetTag: &router.RoutingRule_Tag{
+			Tag: outboundTag,
+		},
+	}
+}
+
+func ruleTags(rules []*router.RoutingRule) []string {
+	tags := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		tags = append(tags, rule.RuleTag)
+	}
+	return tags
+}
+
+func TestRoutingService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	r := new(router.Router)
+	common.Must(r.Init(context.Background(), &router.Config{
+		Rule: []*router.RoutingRule{
+			domainRule("default", "v2ray.com", "direct"),
+		},
+	}, mocks.NewDNSClient(mockCtl), nil))
+
+	s := NewRoutingServer(r)
+	ctx := context.Background()
+	routeCtx := session.ContextWithOutbound(ctx, &session.Outbound{Target: net.TCPDestination(net.DomainAddress("www.v2ray.com"), 443)})
+
+	pickRoute := func() string {
+		route, err := r.PickRoute(routing_session.AsRoutingContext(routeCtx))
+		common.Must(err)
+		return route.GetOutboundTag()
+	}
+
+	if tag := pickRoute(); tag != "direct" {
+		t.Error("expect tag 'direct', but actually ", tag)
+	}
+
+	_, err := s.AddRule(ctx, &AddRuleRequest{
+		Rule:    domainRule("proxy", "www.v2ray.com", "proxy"),
+		Prepend: true,
+	})
+	common.Must(err)
+	if tag := pickRoute(); tag != "proxy" {
+		t.Error("expect tag 'proxy', but actually ", tag)
+	}
+
+	if _, err := s.AddRule(ctx, &AddRuleRequest{Rule: domainRule("proxy", "v2ray.com", "blocked")}); err == nil {
+		t.Error("expect error on duplicated rule tag")
+	}
+	if _, err := s.AddRule(ctx, &AddRuleRequest{Rule: domainRule("", "v2ray.com", "blocked")}); err == nil {
+		t.Error("expect error on empty rule tag")
+	}
+
+	resp, err := s.ListRules(ctx, &ListRulesRequest{})
+	common.Must(err)
+	if tags := ruleTags(resp.Rule); len(tags) != 2 || tags[0] != "proxy" || tags[1] != "default" {
+		t.Error("unexpected rules: ", tags)
+	}
+
+	_, err = s.RemoveRule(ctx, &RemoveRuleRequest{RuleTag: "proxy"})
+	common.Must(err)
+	if tag := pickRoute(); tag != "direct" {
+		t.Error("expect tag 'direct', but actually ", tag)
+	}
+	if _, err := s.RemoveRule(ctx, &RemoveRuleRequest{RuleTag: "proxy"}); err == nil {
+		t.Error("expect error on removing non-existing rule")
+	}
+
+	invalid := &router.RoutingRule{
+		RuleTag: "invalid",
+		TargetTag: &router.RoutingRule_Tag{
+			Tag: "blocked",
+		},
+	}
+	if _, err := s.ReplaceRules(ctx, &ReplaceRulesRequest{Rule: []*router.RoutingRule{domainRule("new", "v2ray.com", "blocked"), invalid}}); err == nil {
+		t.Error("expect error on invalid rule")
+	}
+	if tag := pickRoute(); tag != "direct" {
+		t.Error("expect rules unchanged after failed replacement, but got tag ", tag)
+	}
+
+	_, err = s.ReplaceRules(ctx, &ReplaceRulesRequest{Rule: []*router.RoutingRule{domainRule("new", "v2ray.com", "blocked")}})
+	common.Must(err)
+	if tag := pickRoute(); tag != "blocked" {
+		t.Error("expect tag 'blocked', but actually ", tag)
+	}
+}
+
+func TestRoutingServiceTestRoute(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockDNS := mocks.NewDNSClient(mockCtl)
+	mockDNS.EXPECT().LookupIP(gomock.Eq("example.com")).Return([]net.IP{{10, 0, 0, 1}}, nil).AnyTimes()
+
+	r := new(router.Router)
+	common.Must(r.Init(context.Background(), &router.Config{
+		DomainStrategy: router.Config_IpIfNonMatch,
+		Rule: []*router.RoutingRule{
+			domainRule("domain", "v2ray.com", "direct"),
+			{
+				RuleTag: "private",
+				Cidr: []*router.CIDR{
+					{
+						Ip:     []byte{10, 0, 0, 0},
+						Prefix: 8,
+					},
+				},
+				TargetTag: &router.RoutingRule_Tag{
+					Tag: "blocked",
+				},
+			},
+		},
+	}, mockDNS, nil))
+
+	s := NewRoutingServer(r)
+	ctx := context.Background()
+
+	testCases := []struct {
+		domain   string
+		response *TestRouteResponse
+	}{
+		{
+			domain: "www.v2ray.com",
+			response: &TestRouteResponse{
+				Matched:     true,
+				RuleIndex:   0,
+				RuleTag:     "domain",
+				OutboundTag: "direct",
+			},
+		},
+		{
+			domain: "example.com",
+			response: &TestRouteResponse{
+				Matched:        true,
+				RuleIndex:      1,
+				RuleTag:        "private",
+				OutboundTag:    "blocked",
+				DomainResolved: true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		resp, err := s.TestRoute(ctx, &TestRouteRequest{
+			TargetAddress: net.NewIPOrDomain(net.DomainAddress(tc.domain)),
+			TargetPort:    443,
+		})
+		common.Must(err)
+		if r := cmp.Diff(resp, tc.response, cmpopts.IgnoreUnexported(TestRouteResponse{})); r != "" {
+			t.Error("unexpected response for ", tc.domain, ": ", r)
+		}
+	}
+
+	resp, err := s.TestRoute(ctx, &TestRouteRequest{
+		TargetAddress: net.NewIPOrDomain(net.ParseAddress("8.8.8.8")),
+		TargetPort:    53,
+		Network:       net.Network_UDP,
+	})
+	common.Must(err)
+	if resp.Matched || resp.RuleIndex != -1 {
+		t.Error("expect no rule matched, but got ", resp)
+	}
+}
@@ -3,8 +3,11 @@
 package router
 
 import (
+	"context"
+
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/features/outbound"
+	"v2ray.com/core/features/routing"
 )
 
 // CIDRList is an alias of []*CIDR to provide sort.Interface.
@@ -48,8 +51,11 @@ func (l *CIDRList) Swap(i int, j int) {
 
 type Rule struct {
 	Tag       string
+	RuleTag   string
 	Balancer  *Balancer
 	Condition Condition
+
+	config *RoutingRule
 }
 
 func (r *Rule) GetTag() (string, error) {
@@ -59,7 +65,7 @@ func (r *Rule) GetTag() (string, error) {
 	return r.Tag, nil
 }
 
-func (r *Rule) Apply(ctx *Context) bool {
+func (r *Rule) Apply(ctx routing.Context) bool {
 	return r.Condition.Apply(ctx)
 }
 
@@ -141,10 +147,31 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 	return conds, nil
 }
 
-func (br *BalancingRule) Build(ohm outbound.Manager) (*Balancer, error) {
-	return &Balancer{
-		selectors: br.OutboundSelector,
-		strategy:  &RandomStrategy{},
-		ohm:       ohm,
-	}, nil
+func (br *BalancingRule) Build(ctx context.Context, ohm outbound.Manager) (*Balancer, error) {
+	b := &Balancer{
+		selectors:   br.OutboundSelector,
+		ohm:         ohm,
+		ctx:         ctx,
+		fallbackTag: br.FallbackTag,
+	}
+
+	switch br.Strategy {
+	case BalancingRule_Random:
+		b.strategy = &RandomStrategy{}
+	case BalancingRule_RoundRobin:
+		b.strategy = &RoundRobinStrategy{}
+	case BalancingRule_LeastPing:
+		b.strategy = &LeastPingStrategy{
+			config:  br.LeastPing,
+			observe: b.observation,
+		}
+	case BalancingRule_LeastLoad:
+		b.strategy = &LeastLoadStrategy{
+			ohm: ohm,
+		}
+	default:
+		return nil, newError("unknown balancing strategy: ", br.Strategy)
+	}
+
+	return b, nil
 }
@@ -6,11 +6,11 @@ package router
 
 import (
 	"context"
+	"sync"
 
 	"v2ray.com/core"
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/net"
-	"v2ray.com/core/common/session"
 	"v2ray.com/core/features/dns"
 	"v2ray.com/core/features/outbound"
 	"v2ray.com/core/features/routing"
@@ -20,7 +20,7 @@ func init() {
 	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
 		r := new(Router)
 		if err := core.RequireFeatures(ctx, func(d dns.Client, ohm outbound.Manager) error {
-			return r.Init(config.(*Config), d, ohm)
+			return r.Init(ctx, config.(*Config), d, ohm)
 		}); err != nil {
 			return nil, err
 		}
@@ -31,93 +31,259 @@ func init() {
 // Router is an implementation of routing.Router.
 type Router struct {
 	domainStrategy Config_DomainStrategy
-	rules          []*Rule
 	balancers      map[string]*Balancer
 	dns            dns.Client
+
+	// access guards rules. The slice is never modified in place, but replaced as a whole on changes.
+	access sync.RWMutex
+	rules  []*Rule
 }
 
 // Init initializes the Router.
-func (r *Router) Init(config *Config, d dns.Client, ohm outbound.Manager) error {
+func (r *Router) Init(ctx context.Context, config *Config, d dns.Client, ohm outbound.Manager) error {
 	r.domainStrategy = config.DomainStrategy
 	r.dns = d
 
 	r.balancers = make(map[string]*Balancer, len(config.BalancingRule))
 	for _, rule := range config.BalancingRule {
-		balancer, err := rule.Build(ohm)
+		balancer, err := rule.Build(ctx, ohm)
 		if err != nil {
 			return err
 		}
 		r.balancers[rule.Tag] = balancer
 	}
 
-	r.rules = make([]*Rule, 0, len(config.Rule))
-	for _, rule := range config.Rule {
-		cond, err := rule.BuildCondition()
-		if err != nil {
-			return err
-		}
-		rr := &Rule{
-			Condition: cond,
-			Tag:       rule.GetTag(),
+	rules, err := r.buildRules(config.Rule)
+	if err != nil {
+		return err
+	}
+	r.rules = rules
+
+	return nil
+}
+
+func (r *Router) buildRule(config *RoutingRule) (*Rule, error) {
+	cond, err := config.BuildCondition()
+	if err != nil {
+		return nil, err
+	}
+	rr := &Rule{
+		Condition: cond,
+		Tag:       config.GetTag(),
+		RuleTag:   config.RuleTag,
+		config:    config,
+	}
+	btag := config.GetBalancingTag()
+	if len(btag) > 0 {
+		brule, found := r.balancers[btag]
+		if !found {
+			return nil, newError("balancer ", btag, " not found")
 		}
-		btag := rule.GetBalancingTag()
-		if len(btag) > 0 {
-			brule, found := r.balancers[btag]
-			if !found {
-				return newError("balancer ", btag, " not found")
+		rr.Balancer = brule
+	}
+	return rr, nil
+}
+
+func (r *Router) buildRules(configs []*RoutingRule) ([]*Rule, error) {
+	rules := make([]*Rule, 0, len(configs))
+	tags := make(map[string]bool)
+	for _, config := range configs {
+		if len(config.RuleTag) > 0 {
+			if tags[config.RuleTag] {
+				return nil, newError("duplicated rule tag: ", config.RuleTag)
 			}
-			rr.Balancer = brule
+			tags[config.RuleTag] = true
+		}
+		rule, err := r.buildRule(config)
+		if err != nil {
+			return nil, err
 		}
-		r.rules = append(r.rules, rr)
+		rules = append(rules, rule)
 	}
+	return rules, nil
+}
 
+// AddRule adds a routing rule to the end of current rules, or to the beginning if prepend is true.
+// The rule must have a unique rule tag.
+func (r *Router) AddRule(config *RoutingRule, prepend bool) error {
+	if len(config.RuleTag) == 0 {
+		return newError("rule tag is required")
+	}
+	rule, err := r.buildRule(config)
+	if err != nil {
+		return err
+	}
+
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	for _, rr := range r.rules {
+		if rr.RuleTag == config.RuleTag {
+			return newError("duplicated rule tag: ", config.RuleTag)
+		}
+	}
+
+	rules := make([]*Rule, 0, len(r.rules)+1)
+	if prepend {
+		rules = append(rules, rule)
+		rules = append(rules, r.rules...)
+	} else {
+		rules = append(rules, r.rules...)
+		rules = append(rules, rule)
+	}
+	r.rules = rules
 	return nil
 }
 
-func (r *Router) PickRoute(ctx context.Context) (string, error) {
-	rule, err := r.pickRouteInternal(ctx)
+// RemoveRule removes the routing rule with the given rule tag.
+func (r *Router) RemoveRule(tag string) error {
+	if len(tag) == 0 {
+		return newError("rule tag is required")
+	}
+
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	for idx, rr := range r.rules {
+		if rr.RuleTag == tag {
+			rules := make([]*Rule, 0, len(r.rules)-1)
+			rules = append(rules, r.rules[:idx]...)
+			rules = append(rules, r.rules[idx+1:]...)
+			r.rules = rules
+			return nil
+		}
+	}
+	return newError("rule ", tag, " not found")
+}
+
+// ReplaceRules replaces all routing rules at once. Current rules are kept if any of the new rules is invalid.
+func (r *Router) ReplaceRules(configs []*RoutingRule) error {
+	rules, err := r.buildRules(configs)
 	if err != nil {
-		return "", err
+		return err
 	}
-	return rule.GetTag()
+
+	r.access.Lock()
+	r.rules = rules
+	r.access.Unlock()
+	return nil
 }
 
-func isDomainOutbound(outbound *session.Outbound) bool {
-	return outbound != nil && outbound.Target.IsValid() && outbound.Target.Address.Family().IsDomain()
+// ListRules returns the configs of current routing rules, in the order of matching.
+func (r *Router) ListRules() []*RoutingRule {
+	rules := r.getRules()
+	configs := make([]*RoutingRule, 0, len(rules))
+	for _, rule := range rules {
+		configs = append(configs, rule.config)
+	}
+	return configs
+}
+
+func (r *Router) getRules() []*Rule {
+	r.access.RLock()
+	defer r.access.RUnlock()
+	return r.rules
 }
 
 // PickRoute implements routing.Router.
-func (r *Router) pickRouteInternal(ctx context.Context) (*Rule, error) {
-	sessionContext := &Context{
-		Inbound:  session.InboundFromContext(ctx),
-		Outbound: session.OutboundFromContext(ctx),
-		Content:  session.ContentFromContext(ctx),
+func (r *Router) PickRoute(ctx routing.Context) (routing.Route, error) {
+	_, rule, ctx, err := r.pickRouteInternal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := rule.GetTag()
+	if err != nil {
+		return nil, err
+	}
+	return &Route{
+		Context:     ctx,
+		outboundTag: tag,
+		balancerTag: rule.config.GetBalancingTag(),
+		ruleTag:     rule.RuleTag,
+	}, nil
+}
+
+// RouteTestResult explains how a connection is routed.
+type RouteTestResult struct {
+	// RuleIndex is the index of the matched rule, or -1 if no rule matches.
+	RuleIndex int
+	RuleTag   string
+	// OutboundTag is the outbound of the matched rule. It is empty if the rule targets a balancer, unless the
+	// balancer has no candidates and falls back to a fixed outbound.
+	OutboundTag string
+	BalancerTag string
+	// BalancerCandidates are the outbounds that the balancer picks from when the connection is actually routed.
+	BalancerCandidates []string
+	DomainResolved     bool
+}
+
+// TestRoute routes the connection the same way as PickRoute, and reports the matched rule and its outcome.
+// Balancers are not asked to pick an outbound, so that testing doesn't affect their state.
+func (r *Router) TestRoute(ctx routing.Context) (*RouteTestResult, error) {
+	idx, rule, ctx, err := r.pickRouteInternal(ctx)
+	result := &RouteTestResult{
+		RuleIndex: idx,
+	}
+	if rctx, ok := ctx.(*resolvableContext); ok {
+		result.DomainResolved = rctx.resolved
+	}
+	if err == common.ErrNoClue {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
 	}
 
+	result.RuleTag = rule.RuleTag
+	result.BalancerTag = rule.config.GetBalancingTag()
+	if rule.Balancer == nil {
+		result.OutboundTag = rule.Tag
+		return result, nil
+	}
+	tags, err := rule.Balancer.candidates()
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) > 0 {
+		result.BalancerCandidates = tags
+		return result, nil
+	}
+	tag, err := rule.Balancer.fallback(newError("no available outbounds selected"))
+	if err != nil {
+		return nil, err
+	}
+	result.OutboundTag = tag
+	return result, nil
+}
+
+// pickRouteInternal returns the first rule that matches the given context, along with its index in current rules.
+// The returned context is the one that the rules were applied on, which may carry resolved IPs of target domain.
+func (r *Router) pickRouteInternal(ctx routing.Context) (int, *Rule, routing.Context, error) {
 	if r.domainStrategy == Config_IpOnDemand {
-		sessionContext.dnsClient = r.dns
+		ctx = &resolvableContext{Context: ctx, dnsClient: r.dns}
 	}
 
-	for _, rule := range r.rules {
-		if rule.Apply(sessionContext) {
-			return rule, nil
+	rules := r.getRules()
+	for idx, rule := range rules {
+		if rule.Apply(ctx) {
+			return idx, rule, ctx, nil
 		}
 	}
 
-	if r.domainStrategy != Config_IpIfNonMatch || !isDomainOutbound(sessionContext.Outbound) {
-		return nil, common.ErrNoClue
+	if r.domainStrategy != Config_IpIfNonMatch || len(ctx.GetTargetDomain()) == 0 {
+		return -1, nil, ctx, common.ErrNoClue
 	}
 
-	sessionContext.dnsClient = r.dns
+	ctx = &resolvableContext{Context: ctx, dnsClient: r.dns}
 
 	// Try applying rules again if we have IPs.
-	for _, rule := range r.rules {
-		if rule.Apply(sessionContext) {
-			return rule, nil
+	for idx, rule := range rules {
+		if rule.Apply(ctx) {
+			return idx, rule, ctx, nil
 		}
 	}
 
-	return nil, common.ErrNoClue
+	return -1, nil, ctx, common.ErrNoClue
 }
 
 // Start implements common.Runnable.
@@ -135,32 +301,53 @@ func (*Router) Type() interface{} {
 	return routing.RouterType()
 }
 
-type Context struct {
-	Inbound  *session.Inbound
-	Outbound *session.Outbound
-	Content  *session.Content
+// Route is an implementation of routing.Route.
+type Route struct {
+	routing.Context
+	outboundTag string
+	balancerTag string
+	ruleTag     string
+}
 
-	dnsClient dns.Client
+// GetOutboundTag implements routing.Route.
+func (r *Route) GetOutboundTag() string {
+	return r.outboundTag
 }
 
-func (c *Context) GetTargetIPs() []net.IP {
-	if c.Outbound == nil || !c.Outbound.Target.IsValid() {
-		return nil
-	}
+// GetBalancerTag implements routing.Route.
+func (r *Route) GetBalancerTag() string {
+	return r.balancerTag
+}
+
+// GetRuleTag implements routing.Route.
+func (r *Route) GetRuleTag() string {
+	return r.ruleTag
+}
+
+// resolvableContext is a routing.Context that resolves target domain to IPs on demand.
+type resolvableContext struct {
+	routing.Context
+	dnsClient   dns.Client
+	resolvedIPs []net.IP
+	// resolved is set when the target domain has been looked up for IPs.
+	resolved bool
+}
 
-	if c.Outbound.Target.Address.Family().IsIP() {
-		return []net.IP{c.Outbound.Target.Address.IP()}
+// GetTargetIPs implements routing.Context.
+func (ctx *resolvableContext) GetTargetIPs() []net.IP {
+	if ips := ctx.Context.GetTargetIPs(); len(ips) > 0 {
+		return ips
 	}
 
-	if len(c.Outbound.ResolvedIPs) > 0 {
-		return c.Outbound.ResolvedIPs
+	if len(ctx.resolvedIPs) > 0 {
+		return ctx.resolvedIPs
 	}
 
-	if c.dnsClient != nil {
-		domain := c.Outbound.Target.Address.Domain()
-		ips, err := c.dnsClient.LookupIP(domain)
+	if domain := ctx.GetTargetDomain(); len(domain) > 0 {
+		ctx.resolved = true
+		ips, err := ctx.dnsClient.LookupIP(domain)
 		if err == nil {
-			c.Outbound.ResolvedIPs = ips
+			ctx.resolvedIPs = ips
 			return ips
 		}
 		newError("resolve ip for ", domain).Base(err).WriteToLog()
@@ -5,11 +5,16 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"v2ray.com/core"
+	"v2ray.com/core/app/observatory"
 	. "v2ray.com/core/app/router"
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/session"
+	"v2ray.com/core/features/extension"
 	"v2ray.com/core/features/outbound"
+	routing_session "v2ray.com/core/features/routing/session"
 	"v2ray.com/core/testing/mocks"
 )
 
@@ -18,6 +23,28 @@ type mockOutboundManager struct {
 	outbound.HandlerSelector
 }
 
+type mockObservatory struct {
+	result *observatory.ObservationResult
+}
+
+func (*mockObservatory) Type() interface{} {
+	return extension.ObservatoryType()
+}
+
+func (*mockObservatory) Start() error {
+	return nil
+}
+
+func (*mockObservatory) Close() error {
+	return nil
+}
+
+func (o *mockObservatory) GetObservation(ctx context.Context) (proto.Message, error) {
+	return o.result, nil
+}
+
+const v2rayKey core.V2rayKey = 1
+
 func TestSimpleRouter(t *testing.T) {
 	config := &Config{
 		Rule: []*RoutingRule{
@@ -38,14 +65,15 @@ func TestSimpleRouter(t *testing.T) {
 	mockHs := mocks.NewOutboundHandlerSelector(mockCtl)
 
 	r := new(Router)
-	common.Must(r.Init(config, mockDns, &mockOutboundManager{
+	common.Must(r.Init(context.TODO(), config, mockDns, &mockOutboundManager{
 		Manager:         mockOhm,
 		HandlerSelector: mockHs,
 	}))
 
 	ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("v2ray.com"), 80)})
-	tag, err := r.PickRoute(ctx)
+	route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
 	common.Must(err)
+	tag := route.GetOutboundTag()
 	if tag != "test" {
 		t.Error("expect tag 'test', bug actually ", tag)
 	}
@@ -79,19 +107,131 @@ func TestSimpleBalancer(t *testing.T) {
 	mockHs.EXPECT().Select(gomock.Eq([]string{"test-"})).Return([]string{"test"})
 
 	r := new(Router)
-	common.Must(r.Init(config, mockDns, &mockOutboundManager{
+	common.Must(r.Init(context.TODO(), config, mockDns, &mockOutboundManager{
 		Manager:         mockOhm,
 		HandlerSelector: mockHs,
 	}))
 
 	ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("v2ray.com"), 80)})
-	tag, err := r.PickRoute(ctx)
+	route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
 	common.Must(err)
+	tag := route.GetOutboundTag()
 	if tag != "test" {
 		t.Error("expect tag 'test', bug actually ", tag)
 	}
 }
 
+func TestBalancerSkipsUnhealthyOutbound(t *testing.T) {
+	config := &Config{
+		Rule: []*RoutingRule{
+			{
+				TargetTag: &RoutingRule_BalancingTag{
+					BalancingTag: "balance",
+				},
+				Networks: []net.Network{net.Network_TCP},
+			},
+		},
+		BalancingRule: []*BalancingRule{
+			{
+				Tag:              "balance",
+				OutboundSelector: []string{"test-"},
+			},
+		},
+	}
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockDns := mocks.NewDNSClient(mockCtl)
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockHs := mocks.NewOutboundHandlerSelector(mockCtl)
+
+	mockHs.EXPECT().Select(gomock.Eq([]string{"test-"})).Return([]string{"test-1", "test-2", "test-3"}).AnyTimes()
+
+	v, err := core.New(&core.Config{})
+	common.Must(err)
+	common.Must(v.AddFeature(&mockObservatory{
+		result: &observatory.ObservationResult{
+			Status: []*observatory.OutboundStatus{
+				{OutboundTag: "test-1", Alive: false},
+				{OutboundTag: "test-2", Alive: true},
+			},
+		},
+	}))
+	vctx := context.WithValue(context.Background(), v2rayKey, v)
+
+	r := new(Router)
+	common.Must(r.Init(vctx, config, mockDns, &mockOutboundManager{
+		Manager:         mockOhm,
+		HandlerSelector: mockHs,
+	}))
+
+	ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("v2ray.com"), 80)})
+	for i := 0; i < 32; i++ {
+		route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+		common.Must(err)
+		tag := route.GetOutboundTag()
+		if tag == "test-1" {
+			t.Error("expect unhealthy outbound 'test-1' to be skipped")
+		}
+	}
+}
+
+func TestTestRouteDoesNotPickBalancerOutbound(t *testing.T) {
+	config := &Config{
+		Rule: []*RoutingRule{
+			{
+				RuleTag: "balanced",
+				TargetTag: &RoutingRule_BalancingTag{
+					BalancingTag: "balance",
+				},
+				Networks: []net.Network{net.Network_TCP},
+			},
+		},
+		BalancingRule: []*BalancingRule{
+			{
+				Tag:              "balance",
+				OutboundSelector: []string{"test-"},
+				Strategy:         BalancingRule_RoundRobin,
+			},
+		},
+	}
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockDns := mocks.NewDNSClient(mockCtl)
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockHs := mocks.NewOutboundHandlerSelector(mockCtl)
+
+	mockHs.EXPECT().Select(gomock.Eq([]string{"test-"})).Return([]string{"test-2", "test-1"}).AnyTimes()
+
+	r := new(Router)
+	common.Must(r.Init(context.TODO(), config, mockDns, &mockOutboundManager{
+		Manager:         mockOhm,
+		HandlerSelector: mockHs,
+	}))
+
+	ctx := routing_session.AsRoutingContext(session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("v2ray.com"), 80)}))
+	for i := 0; i < 3; i++ {
+		result, err := r.TestRoute(ctx)
+		common.Must(err)
+		if result.RuleTag != "balanced" || result.BalancerTag != "balance" || result.OutboundTag != "" {
+			t.Error("unexpected result: ", result)
+		}
+		if len(result.BalancerCandidates) != 2 || result.BalancerCandidates[0] != "test-2" || result.BalancerCandidates[1] != "test-1" {
+			t.Error("unexpected candidates: ", result.BalancerCandidates)
+		}
+	}
+
+	// Round robin starts from the first outbound, as testing doesn't advance it.
+	route, err := r.PickRoute(ctx)
+	common.Must(err)
+	if tag := route.GetOutboundTag(); tag != "test-1" {
+		t.Error("expect tag 'test-1', but actually ", tag)
+	}
+}
+
 func TestIPOnDemand(t *testing.T) {
 	config := &Config{
 		DomainStrategy: Config_IpOnDemand,
@@ -117,11 +257,12 @@ func TestIPOnDemand(t *testing.T) {
 	mockDns.EXPECT().LookupIP(gomock.Eq("v2ray.com")).Return([]net.IP{{192, 168, 0, 1}}, nil).AnyTimes()
 
 	r := new(Router)
-	common.Must(r.Init(config, mockDns, nil))
+	common.Must(r.Init(context.TODO(), config, mockDns, nil))
 
 	ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("v2ray.com"), 80)})
-	tag, err := r.PickRoute(ctx)
+	route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
 	common.Must(err)
+	tag := route.GetOutboundTag()
 	if tag != "test" {
 		t.Error("expect tag 'test', bug actually ", tag)
 	}
@@ -152,11 +293,12 @@ func TestIPIfNonMatchDomain(t *testing.T) {
 	mockDns.EXPECT().LookupIP(gomock.Eq("v2ray.com")).Return([]net.IP{{192, 168, 0, 1}}, nil).AnyTimes()
 
 	r := new(Router)
-	common.Must(r.Init(config, mockDns, nil))
+	common.Must(r.Init(context.TODO(), config, mockDns, nil))
 
 	ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("v2ray.com"), 80)})
-	tag, err := r.PickRoute(ctx)
+	route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
 	common.Must(err)
+	tag := route.GetOutboundTag()
 	if tag != "test" {
 		t.Error("expect tag 'test', bug actually ", tag)
 	}
@@ -186,11 +328,12 @@ func TestIPIfNonMatchIP(t *testing.T) {
 	mockDns := mocks.NewDNSClient(mockCtl)
 
 	r := new(Router)
-	common.Must(r.Init(config, mockDns, nil))
+	common.Must(r.Init(context.TODO(), config, mockDns, nil))
 
 	ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.LocalHostIP, 80)})
-	tag, err := r.PickRoute(ctx)
+	route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
 	common.Must(err)
+	tag := route.GetOutboundTag()
 	if tag != "test" {
 		t.Error("expect tag 'test', bug actually ", tag)
 	}
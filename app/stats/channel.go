@@ -0,0 +1,127 @@
+// +build !confonly
+
+package stats
+
+import (
+	"sync"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/signal/done"
+)
+
+type subscriber struct {
+	channel chan interface{}
+	done    *done.Instance
+}
+
+// Channel is an implementation of stats.Channel.
+type Channel struct {
+	access      sync.RWMutex
+	subscribers map[chan interface{}]*subscriber
+	done        *done.Instance
+
+	blocking        bool
+	subscriberLimit int
+	bufferSize      int
+}
+
+// NewChannel creates a Channel with the given config.
+func NewChannel(config *ChannelConfig) *Channel {
+	c := &Channel{
+		subscribers: make(map[chan interface{}]*subscriber),
+		done:        done.New(),
+		bufferSize:  64,
+	}
+	if config != nil {
+		c.blocking = config.Blocking
+		c.subscriberLimit = int(config.SubscriberLimit)
+		if config.BufferSize > 0 {
+			c.bufferSize = int(config.BufferSize)
+		}
+	}
+	return c
+}
+
+// Subscribers implements stats.Channel.
+func (c *Channel) Subscribers() int {
+	c.access.RLock()
+	defer c.access.RUnlock()
+	return len(c.subscribers)
+}
+
+// Subscribe implements stats.Channel.
+func (c *Channel) Subscribe() (chan interface{}, error) {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	if c.done.Done() {
+		return nil, newError("channel is closed")
+	}
+	if c.subscriberLimit > 0 && len(c.subscribers) >= c.subscriberLimit {
+		return nil, newError("number of subscribers has reached limit ", c.subscriberLimit)
+	}
+	s := &subscriber{
+		channel: make(chan interface{}, c.bufferSize),
+		done:    done.New(),
+	}
+	c.subscribers[s.channel] = s
+	return s.channel, nil
+}
+
+// Unsubscribe implements stats.Channel.
+func (c *Channel) Unsubscribe(ch chan interface{}) error {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	s, found := c.subscribers[ch]
+	if !found {
+		return newError("subscriber not found")
+	}
+	delete(c.subscribers, ch)
+	common.Must(s.done.Close())
+	return nil
+}
+
+// Publish implements stats.Channel. In blocking mode, it waits until all subscribers receive the message.
+func (c *Channel) Publish(msg interface{}) {
+	c.access.RLock()
+	subscribers := make([]*subscriber, 0, len(c.subscribers))
+	for _, s := range c.subscribers {
+		subscribers = append(subscribers, s)
+	}
+	c.access.RUnlock()
+
+	for _, s := range subscribers {
+		if c.blocking {
+			select {
+			case s.channel <- msg:
+			case <-s.done.Wait():
+			case <-c.done.Wait():
+				return
+			}
+			continue
+		}
+		select {
+		case s.channel <- msg:
+		default:
+		}
+	}
+}
+
+// Start implements common.Runnable.
+func (c *Channel) Start() error {
+	return nil
+}
+
+// Close implements common.Closable. It unregisters all subscribers.
+func (c *Channel) Close() error {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	common.Must(c.done.Close())
+	for ch, s := range c.subscribers {
+		delete(c.subscribers, ch)
+		common.Must(s.done.Close())
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package stats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "v2ray.com/core/app/stats"
+	"v2ray.com/core/common"
+	"v2ray.com/core/features/stats"
+)
+
+func TestStatsChannel(t *testing.T) {
+	raw, err := common.CreateObject(context.Background(), &Config{})
+	common.Must(err)
+
+	m := raw.(stats.ChannelManager)
+	c, err := m.RegisterChannel("test.channel")
+	common.Must(err)
+	if _, err := m.RegisterChannel("test.channel"); err == nil {
+		t.Error("expect error on duplicate channel")
+	}
+	if m.GetChannel("test.channel") != c {
+		t.Error("unexpected channel returned by GetChannel")
+	}
+
+	sub1, err := c.Subscribe()
+	common.Must(err)
+	sub2, err := c.Subscribe()
+	common.Must(err)
+	if c.Subscribers() != 2 {
+		t.Error("expect 2 subscribers, but got ", c.Subscribers())
+	}
+
+	c.Publish(1)
+	for _, sub := range []chan interface{}{sub1, sub2} {
+		select {
+		case msg := <-sub:
+			if msg != 1 {
+				t.Error("unexpected message: ", msg)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for message")
+		}
+	}
+
+	common.Must(c.Unsubscribe(sub2))
+	c.Publish(2)
+	if msg := <-sub1; msg != 2 {
+		t.Error("unexpected message: ", msg)
+	}
+	select {
+	case msg := <-sub2:
+		t.Error("unexpected message after unsubscribe: ", msg)
+	default:
+	}
+
+	common.Must(m.UnregisterChannel("test.channel"))
+	if m.GetChannel("test.channel") != nil {
+		t.Error("expect channel to be unregistered")
+	}
+	if _, err := c.Subscribe(); err == nil {
+		t.Error("expect error on subscribing to a closed channel")
+	}
+}
+
+func TestStatsChannelNonBlocking(t *testing.T) {
+	c := NewChannel(&ChannelConfig{
+		SubscriberLimit: 1,
+		BufferSize:      2,
+	})
+	sub, err := c.Subscribe()
+	common.Must(err)
+	if _, err := c.Subscribe(); err == nil {
+		t.Error("expect error on exceeding subscriber limit")
+	}
+
+	for i := 0; i < 4; i++ {
+		c.Publish(i)
+	}
+	if len(sub) != 2 {
+		t.Error("expect 2 buffered messages, but got ", len(sub))
+	}
+}
+
+func TestStatsChannelBlocking(t *testing.T) {
+	c := NewChannel(&ChannelConfig{
+		Blocking:   true,
+		BufferSize: 1,
+	})
+	sub, err := c.Subscribe()
+	common.Must(err)
+
+	published := make(chan struct{})
+	go func() {
+		c.Publish(1)
+		c.Publish(2)
+		close(published)
+	}()
+
+	select {
+	case <-published:
+		t.Fatal("expect publisher to be blocked")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	if msg := <-sub; msg != 1 {
+		t.Error("unexpected message: ", msg)
+	}
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for publisher")
+	}
+	if msg := <-sub; msg != 2 {
+		t.Error("unexpected message: ", msg)
+	}
+}
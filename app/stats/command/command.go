@@ -6,7 +6,11 @@ package command
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 
 	grpc "google.golang.org/grpc"
@@ -51,29 +55,81 @@ func (s *statsServer) GetStats(ctx context.Context, request *GetStatsRequest) (*
 }
 
 func (s *statsServer) QueryStats(ctx context.Context, request *QueryStatsRequest) (*QueryStatsResponse, error) {
+	patterns := request.Patterns
+	if len(request.Pattern) > 0 || len(patterns) == 0 {
+		patterns = append([]string{request.Pattern}, patterns...)
+	}
+	matcherType := strmatcher.Substr
+	if request.Regexp {
+		matcherType = strmatcher.Regex
+	}
+	matchers := make([]strmatcher.Matcher, 0, len(patterns))
+	for _, pattern := range patterns {
+		matcher, err := matcherType.New(pattern)
+		if err != nil {
+			return nil, newError("invalid pattern ", pattern).Base(err)
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	manager, ok := s.stats.(*stats.Manager)
+	if !ok {
+		return nil, newError("QueryStats only works its own stats.Manager.")
+	}
+
+	values := manager.SnapshotCounters(func(name string) bool {
+		for _, matcher := range matchers {
+			if matcher.Match(name) {
+				return true
+			}
+		}
+		return false
+	}, request.Reset_)
+
+	response := &QueryStatsResponse{}
+	for name, value := range values {
+		response.Stat = append(response.Stat, &Stat{
+			Name:  name,
+			Value: value,
+		})
+	}
+	sort.Slice(response.Stat, func(i, j int) bool {
+		return response.Stat[i].Name < response.Stat[j].Name
+	})
+
+	return response, nil
+}
+
+func (s *statsServer) QueryMetrics(ctx context.Context, request *QueryMetricsRequest) (*QueryMetricsResponse, error) {
 	matcher, err := strmatcher.Substr.New(request.Pattern)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &QueryStatsResponse{}
-
 	manager, ok := s.stats.(*stats.Manager)
 	if !ok {
-		return nil, newError("QueryStats only works its own stats.Manager.")
+		return nil, newError("QueryMetrics only works its own stats.Manager.")
 	}
 
-	manager.Visit(func(name string, c feature_stats.Counter) bool {
+	response := &QueryMetricsResponse{}
+	manager.VisitGauges(func(name string, g feature_stats.Gauge) bool {
 		if matcher.Match(name) {
-			var value int64
-			if request.Reset_ {
-				value = c.Set(0)
-			} else {
-				value = c.Value()
-			}
-			response.Stat = append(response.Stat, &Stat{
+			response.Gauge = append(response.Gauge, &Stat{
 				Name:  name,
-				Value: value,
+				Value: g.Value(),
+			})
+		}
+		return true
+	})
+	manager.VisitHistograms(func(name string, h feature_stats.Histogram) bool {
+		if matcher.Match(name) {
+			snapshot := h.Snapshot()
+			response.Histogram = append(response.Histogram, &Histogram{
+				Name:        name,
+				Bucket:      snapshot.Buckets,
+				BucketCount: snapshot.Counts,
+				Count:       snapshot.Count,
+				Sum:         snapshot.Sum,
 			})
 		}
 		return true
@@ -104,6 +160,93 @@ func (s *statsServer) GetSysStats(ctx context.Context, request *SysStatsRequest)
 	return response, nil
 }
 
+func (s *statsServer) GetUsersOnline(ctx context.Context, request *GetUsersOnlineRequest) (*GetUsersOnlineResponse, error) {
+	manager, ok := s.stats.(*stats.Manager)
+	if !ok {
+		return nil, newError("GetUsersOnline only works its own stats.Manager.")
+	}
+
+	response := &GetUsersOnlineResponse{}
+	manager.VisitOnlineMaps(func(name string, om feature_stats.OnlineMap) bool {
+		if !strings.HasPrefix(name, "user>>>") || !strings.HasSuffix(name, ">>>online") {
+			return true
+		}
+		if count := om.Count(); count > 0 {
+			response.User = append(response.User, &UserOnline{
+				Email:   strings.TrimSuffix(strings.TrimPrefix(name, "user>>>"), ">>>online"),
+				IpCount: uint32(count),
+			})
+		}
+		return true
+	})
+	sort.Slice(response.User, func(i, j int) bool {
+		return response.User[i].Email < response.User[j].Email
+	})
+
+	return response, nil
+}
+
+func (s *statsServer) GetUserIPs(ctx context.Context, request *GetUserIPsRequest) (*GetUserIPsResponse, error) {
+	var om feature_stats.OnlineMap
+	if manager, ok := s.stats.(feature_stats.OnlineMapManager); ok {
+		om = manager.GetOnlineMap("user>>>" + request.Email + ">>>online")
+	}
+	if om == nil {
+		return nil, newError("online stats of ", request.Email, " not found.")
+	}
+
+	response := &GetUserIPsResponse{}
+	for ip, lastSeen := range om.IPTimeMap() {
+		response.Ip = append(response.Ip, &UserIP{
+			Ip:       ip,
+			LastSeen: lastSeen.Unix(),
+		})
+	}
+	sort.Slice(response.Ip, func(i, j int) bool {
+		return response.Ip[i].Ip < response.Ip[j].Ip
+	})
+
+	return response, nil
+}
+
+func encodeChannelMessage(msg interface{}) string {
+	if b, err := json.Marshal(msg); err == nil {
+		return string(b)
+	}
+	return fmt.Sprint(msg)
+}
+
+func (s *statsServer) SubscribeChannel(request *SubscribeChannelRequest, stream StatsService_SubscribeChannelServer) error {
+	var c feature_stats.Channel
+	if manager, ok := s.stats.(feature_stats.ChannelManager); ok {
+		c = manager.GetChannel(request.Name)
+	}
+	if c == nil {
+		return newError(request.Name, " not found.")
+	}
+
+	sub, err := c.Subscribe()
+	if err != nil {
+		return newError("failed to subscribe to ", request.Name).Base(err)
+	}
+	defer c.Unsubscribe(sub) // nolint: errcheck
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case msg := <-sub:
+			if err := stream.Send(&ChannelMessage{
+				Name:      request.Name,
+				Timestamp: time.Now().Unix(),
+				Content:   encodeChannelMessage(msg),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 type service struct {
 	statsManager feature_stats.Manager
 }
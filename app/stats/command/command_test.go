@@ -90,3 +90,120 @@ func TestQueryStats(t *testing.T) {
 		t.Error(r)
 	}
 }
+
+func TestUsersOnline(t *testing.T) {
+	m, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+
+	om, err := m.RegisterOnlineMap("user>>>alice@v2ray.com>>>online")
+	common.Must(err)
+	om.AddIP("10.0.0.1")
+	om.AddIP("10.0.0.2")
+
+	_, err = m.RegisterOnlineMap("user>>>bob@v2ray.com>>>online")
+	common.Must(err)
+
+	s := NewStatsServer(m)
+	resp, err := s.GetUsersOnline(context.Background(), &GetUsersOnlineRequest{})
+	common.Must(err)
+	if r := cmp.Diff(resp.User, []*UserOnline{
+		{Email: "alice@v2ray.com", IpCount: 2},
+	}, cmpopts.IgnoreUnexported(UserOnline{})); r != "" {
+		t.Error(r)
+	}
+
+	ips, err := s.GetUserIPs(context.Background(), &GetUserIPsRequest{Email: "alice@v2ray.com"})
+	common.Must(err)
+	if len(ips.Ip) != 2 || ips.Ip[0].Ip != "10.0.0.1" || ips.Ip[1].Ip != "10.0.0.2" {
+		t.Error("unexpected IPs: ", ips.Ip)
+	}
+
+	if _, err := s.GetUserIPs(context.Background(), &GetUserIPsRequest{Email: "carol@v2ray.com"}); err == nil {
+		t.Error("expect error for unknown user")
+	}
+}
+
+func TestQueryMetrics(t *testing.T) {
+	m, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+
+	g, err := m.RegisterGauge("outbound>>>direct>>>connections")
+	common.Must(err)
+	g.Set(2)
+	_, err = m.RegisterGauge("inbound>>>socks>>>connections")
+	common.Must(err)
+	h, err := m.RegisterHistogram("outbound>>>direct>>>dial_latency_seconds", []float64{1})
+	common.Must(err)
+	h.Observe(2)
+
+	s := NewStatsServer(m)
+	resp, err := s.QueryMetrics(context.Background(), &QueryMetricsRequest{
+		Pattern: "outbound>>>",
+	})
+	common.Must(err)
+	if r := cmp.Diff(resp.Gauge, []*Stat{
+		{Name: "outbound>>>direct>>>connections", Value: 2},
+	}, cmpopts.IgnoreUnexported(Stat{})); r != "" {
+		t.Error(r)
+	}
+	if r := cmp.Diff(resp.Histogram, []*Histogram{
+		{Name: "outbound>>>direct>>>dial_latency_seconds", Bucket: []float64{1}, BucketCount: []uint64{0}, Count: 1, Sum: 2},
+	}, cmpopts.IgnoreUnexported(Histogram{})); r != "" {
+		t.Error(r)
+	}
+}
+
+func TestQueryStatsPatterns(t *testing.T) {
+	m, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+
+	for name, value := range map[string]int64{
+		"user>>>alice@v2ray.com>>>traffic>>>uplink":   1,
+		"user>>>alice@v2ray.com>>>traffic>>>downlink": 2,
+		"user>>>bob@v2ray.com>>>traffic>>>uplink":     3,
+		"inbound>>>socks>>>traffic>>>uplink":          4,
+		"outbound>>>direct>>>traffic>>>uplink":        5,
+	} {
+		c, err := m.RegisterCounter(name)
+		common.Must(err)
+		c.Set(value)
+	}
+
+	s := NewStatsServer(m)
+	resp, err := s.QueryStats(context.Background(), &QueryStatsRequest{
+		Patterns: []string{`^user>>>alice@v2ray\.com>>>`, `^inbound>>>.*>>>uplink$`},
+		Regexp:   true,
+		Reset_:   true,
+	})
+	common.Must(err)
+	if r := cmp.Diff(resp.Stat, []*Stat{
+		{Name: "inbound>>>socks>>>traffic>>>uplink", Value: 4},
+		{Name: "user>>>alice@v2ray.com>>>traffic>>>downlink", Value: 2},
+		{Name: "user>>>alice@v2ray.com>>>traffic>>>uplink", Value: 1},
+	}, cmpopts.IgnoreUnexported(Stat{})); r != "" {
+		t.Error(r)
+	}
+
+	resp, err = s.QueryStats(context.Background(), &QueryStatsRequest{
+		Pattern:  "bob",
+		Patterns: []string{"outbound>>>"},
+	})
+	common.Must(err)
+	if r := cmp.Diff(resp.Stat, []*Stat{
+		{Name: "outbound>>>direct>>>traffic>>>uplink", Value: 5},
+		{Name: "user>>>bob@v2ray.com>>>traffic>>>uplink", Value: 3},
+	}, cmpopts.IgnoreUnexported(Stat{})); r != "" {
+		t.Error(r)
+	}
+
+	if c := m.GetCounter("user>>>alice@v2ray.com>>>traffic>>>uplink"); c.Value() != 0 {
+		t.Error("expect counter to be reset, but got ", c.Value())
+	}
+
+	if _, err := s.QueryStats(context.Background(), &QueryStatsRequest{
+		Patterns: []string{"("},
+		Regexp:   true,
+	}); err == nil {
+		t.Error("expect error on invalid regexp")
+	}
+}
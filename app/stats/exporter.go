@@ -0,0 +1,311 @@
+// +build !confonly
+
+package stats
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/features/stats"
+)
+
+// metricLabelNames maps the first segment of a stats name to the label of its second segment,
+// for names like "user>>>email>>>traffic>>>uplink" and "outbound>>>tag>>>connections".
+var metricLabelNames = map[string]string{
+	"user":     "user",
+	"inbound":  "inbound",
+	"outbound": "outbound",
+}
+
+type metric struct {
+	name   string
+	labels [][2]string
+	value  interface{}
+}
+
+// parseName splits a stats name into a Prometheus metric name and labels. The 4th segment of a name,
+// if any, becomes the direction label. ok is false if the name is not in a recognized form.
+func parseName(name string) (metricName string, labels [][2]string, ok bool) {
+	parts := strings.Split(name, ">>>")
+	label, found := metricLabelNames[parts[0]]
+	if !found {
+		return "", nil, false
+	}
+	switch len(parts) {
+	case 3:
+		return "v2ray_" + parts[0] + "_" + parts[2], [][2]string{{label, parts[1]}}, true
+	case 4:
+		return "v2ray_" + parts[0] + "_" + parts[2], [][2]string{{label, parts[1]}, {"direction", parts[3]}}, true
+	default:
+		return "", nil, false
+	}
+}
+
+// parseCounterName converts a counter name into a Prometheus metric. Unrecognized names are exported
+// as v2ray_counter with the full name as a label.
+func parseCounterName(name string, value int64) metric {
+	if metricName, labels, ok := parseName(name); ok {
+		if len(labels) == 2 {
+			// Traffic counters are named like "user>>>email>>>traffic>>>uplink".
+			metricName += "_bytes"
+		}
+		return metric{
+			name:   metricName + "_total",
+			labels: labels,
+			value:  value,
+		}
+	}
+	return metric{
+		name:   "v2ray_counter",
+		labels: [][2]string{{"name", name}},
+		value:  value,
+	}
+}
+
+// parseGaugeName converts a gauge name into a Prometheus metric. Unrecognized names are exported
+// as v2ray_gauge with the full name as a label.
+func parseGaugeName(name string, value int64) metric {
+	if metricName, labels, ok := parseName(name); ok {
+		return metric{
+			name:   metricName,
+			labels: labels,
+			value:  value,
+		}
+	}
+	return metric{
+		name:   "v2ray_gauge",
+		labels: [][2]string{{"name", name}},
+		value:  value,
+	}
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func writeMetric(w *bytes.Buffer, name string, labels [][2]string, value interface{}) {
+	w.WriteString(name)
+	if len(labels) > 0 {
+		w.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, "%s=\"%s\"", l[0], labelValueReplacer.Replace(l[1]))
+		}
+		w.WriteByte('}')
+	}
+	fmt.Fprintf(w, " %v\n", value)
+}
+
+// writeFamilies writes metrics grouped by their names. Metrics of the same name are written in the given order.
+func writeFamilies(w *bytes.Buffer, metricType string, metrics []metric) {
+	var families []string
+	grouped := make(map[string][]metric)
+	for _, mt := range metrics {
+		if _, found := grouped[mt.name]; !found {
+			families = append(families, mt.name)
+		}
+		grouped[mt.name] = append(grouped[mt.name], mt)
+	}
+	sort.Strings(families)
+
+	for _, family := range families {
+		fmt.Fprintf(w, "# TYPE %s %s\n", family, metricType)
+		for _, mt := range grouped[family] {
+			if s, ok := mt.value.(stats.HistogramSnapshot); ok {
+				writeHistogram(w, mt.name, mt.labels, s)
+				continue
+			}
+			writeMetric(w, mt.name, mt.labels, mt.value)
+		}
+	}
+}
+
+func sortedNames(values map[string]int64) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (m *Manager) writeCounters(w *bytes.Buffer) {
+	counters := make(map[string]int64)
+	m.Visit(func(name string, c stats.Counter) bool {
+		counters[name] = c.Value()
+		return true
+	})
+
+	var metrics []metric
+	for _, name := range sortedNames(counters) {
+		metrics = append(metrics, parseCounterName(name, counters[name]))
+	}
+	writeFamilies(w, "counter", metrics)
+}
+
+func (m *Manager) writeGauges(w *bytes.Buffer) {
+	gauges := make(map[string]int64)
+	m.VisitGauges(func(name string, g stats.Gauge) bool {
+		gauges[name] = g.Value()
+		return true
+	})
+
+	var metrics []metric
+	for _, name := range sortedNames(gauges) {
+		metrics = append(metrics, parseGaugeName(name, gauges[name]))
+	}
+	writeFamilies(w, "gauge", metrics)
+}
+
+func (m *Manager) writeOnlineMaps(w *bytes.Buffer) {
+	counts := make(map[string]int64)
+	m.VisitOnlineMaps(func(name string, om stats.OnlineMap) bool {
+		counts[name] = int64(om.Count())
+		return true
+	})
+
+	var metrics []metric
+	for _, name := range sortedNames(counts) {
+		labels := [][2]string{{"name", name}}
+		// Online maps are named like "user>>>email>>>online".
+		if _, l, ok := parseName(name); ok {
+			labels = l
+		}
+		metrics = append(metrics, metric{
+			name:   "v2ray_online_ips",
+			labels: labels,
+			value:  counts[name],
+		})
+	}
+	writeFamilies(w, "gauge", metrics)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// writeHistogram writes the buckets, sum and count of a histogram.
+func writeHistogram(w *bytes.Buffer, name string, labels [][2]string, s stats.HistogramSnapshot) {
+	withBound := func(bound string) [][2]string {
+		l := make([][2]string, 0, len(labels)+1)
+		return append(append(l, labels...), [2]string{"le", bound})
+	}
+	for i, bound := range s.Buckets {
+		writeMetric(w, name+"_bucket", withBound(formatFloat(bound)), s.Counts[i])
+	}
+	writeMetric(w, name+"_bucket", withBound("+Inf"), s.Count)
+	writeMetric(w, name+"_sum", labels, formatFloat(s.Sum))
+	writeMetric(w, name+"_count", labels, s.Count)
+}
+
+func (m *Manager) writeHistograms(w *bytes.Buffer) {
+	snapshots := make(map[string]stats.HistogramSnapshot)
+	m.VisitHistograms(func(name string, h stats.Histogram) bool {
+		snapshots[name] = h.Snapshot()
+		return true
+	})
+
+	names := make([]string, 0, len(snapshots))
+	for name := range snapshots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var metrics []metric
+	for _, name := range names {
+		metricName, labels, ok := parseName(name)
+		if !ok {
+			metricName, labels = "v2ray_histogram", [][2]string{{"name", name}}
+		}
+		metrics = append(metrics, metric{
+			name:   metricName,
+			labels: labels,
+			value:  snapshots[name],
+		})
+	}
+
+	writeFamilies(w, "histogram", metrics)
+}
+
+func (m *Manager) writeSysStats(w *bytes.Buffer) {
+	var rtm runtime.MemStats
+	runtime.ReadMemStats(&rtm)
+
+	gauge := func(name string, value interface{}) {
+		fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+		writeMetric(w, name, nil, value)
+	}
+	counter := func(name string, value interface{}) {
+		fmt.Fprintf(w, "# TYPE %s counter\n", name)
+		writeMetric(w, name, nil, value)
+	}
+
+	gauge("v2ray_uptime_seconds", uint64(time.Since(m.startTime).Seconds()))
+	gauge("v2ray_goroutines", runtime.NumGoroutine())
+	gauge("v2ray_memory_alloc_bytes", rtm.Alloc)
+	counter("v2ray_memory_alloc_bytes_total", rtm.TotalAlloc)
+	gauge("v2ray_memory_sys_bytes", rtm.Sys)
+	counter("v2ray_memory_mallocs_total", rtm.Mallocs)
+	counter("v2ray_memory_frees_total", rtm.Frees)
+	gauge("v2ray_memory_live_objects", rtm.Mallocs-rtm.Frees)
+	counter("v2ray_gc_total", rtm.NumGC)
+	counter("v2ray_gc_pause_seconds_total", float64(rtm.PauseTotalNs)/float64(time.Second))
+}
+
+// ServeHTTP implements http.Handler. It writes all counters, gauges, histograms, online maps and runtime stats in Prometheus text format.
+func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var b bytes.Buffer
+	m.writeCounters(&b)
+	m.writeGauges(&b)
+	m.writeHistograms(&b)
+	m.writeOnlineMaps(&b)
+	m.writeSysStats(&b)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(b.Bytes()) // nolint: errcheck
+}
+
+type exporter struct {
+	server   *http.Server
+	listener net.Listener
+}
+
+func newExporter(config *ExporterConfig, handler http.Handler) (*exporter, error) {
+	path := config.Path
+	if len(path) == 0 {
+		path = "/metrics"
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+
+	listener, err := net.Listen("tcp", config.Listen)
+	if err != nil {
+		return nil, newError("failed to listen on ", config.Listen).Base(err)
+	}
+	newError("stats exporter listening on ", listener.Addr(), path).AtInfo().WriteToLog()
+
+	e := &exporter{
+		server: &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: time.Second * 8,
+		},
+		listener: listener,
+	}
+	go func() {
+		if err := e.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			newError("stats exporter stopped").Base(err).AtWarning().WriteToLog()
+		}
+	}()
+	return e, nil
+}
+
+func (e *exporter) Close() error {
+	return e.server.Close()
+}
@@ -0,0 +1,83 @@
+// +build !confonly
+
+package stats
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"v2ray.com/core/features/stats"
+)
+
+// Gauge is an implementation of stats.Gauge.
+type Gauge struct {
+	value int64
+}
+
+// Value implements stats.Gauge.
+func (g *Gauge) Value() int64 {
+	return atomic.LoadInt64(&g.value)
+}
+
+// Set implements stats.Gauge.
+func (g *Gauge) Set(newValue int64) int64 {
+	return atomic.SwapInt64(&g.value, newValue)
+}
+
+// Add implements stats.Gauge.
+func (g *Gauge) Add(delta int64) int64 {
+	return atomic.AddInt64(&g.value, delta)
+}
+
+// Histogram is an implementation of stats.Histogram.
+type Histogram struct {
+	access  sync.Mutex
+	buckets []float64
+	// counts has one more element than buckets, for values greater than all bucket bounds.
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogram creates a Histogram with the given bucket bounds.
+func NewHistogram(buckets []float64) *Histogram {
+	bounds := make([]float64, len(buckets))
+	copy(bounds, buckets)
+	sort.Float64s(bounds)
+	return &Histogram{
+		buckets: bounds,
+		counts:  make([]uint64, len(bounds)+1),
+	}
+}
+
+// Observe implements stats.Histogram.
+func (h *Histogram) Observe(value float64) {
+	idx := sort.SearchFloat64s(h.buckets, value)
+
+	h.access.Lock()
+	defer h.access.Unlock()
+
+	h.counts[idx]++
+	h.count++
+	h.sum += value
+}
+
+// Snapshot implements stats.Histogram.
+func (h *Histogram) Snapshot() stats.HistogramSnapshot {
+	h.access.Lock()
+	defer h.access.Unlock()
+
+	s := stats.HistogramSnapshot{
+		Buckets: h.buckets,
+		Counts:  make([]uint64, len(h.buckets)),
+		Count:   h.count,
+		Sum:     h.sum,
+	}
+	var cumulative uint64
+	for i := range h.buckets {
+		cumulative += h.counts[i]
+		s.Counts[i] = cumulative
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package stats_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "v2ray.com/core/app/stats"
+	"v2ray.com/core/common"
+)
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram([]float64{1, 0.1, 10})
+	for _, v := range []float64{0.05, 0.1, 0.5, 5, 50} {
+		h.Observe(v)
+	}
+
+	s := h.Snapshot()
+	if s.Count != 5 || s.Sum != 55.65 {
+		t.Error("unexpected count and sum: ", s.Count, " ", s.Sum)
+	}
+	expected := []uint64{2, 3, 4}
+	for i, c := range s.Counts {
+		if s.Buckets[i] != []float64{0.1, 1, 10}[i] || c != expected[i] {
+			t.Error("unexpected bucket ", s.Buckets[i], ": ", c)
+		}
+	}
+}
+
+func TestExportMetrics(t *testing.T) {
+	m, err := NewManager(context.Background(), &Config{})
+	common.Must(err)
+
+	g, err := m.RegisterGauge("inbound>>>socks>>>connections")
+	common.Must(err)
+	g.Add(3)
+	g.Add(-1)
+
+	h, err := m.RegisterHistogram("outbound>>>direct>>>dial_latency_seconds", []float64{0.1, 1})
+	common.Must(err)
+	h.Observe(0.5)
+
+	recorder := httptest.NewRecorder()
+	m.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+
+	for _, line := range []string{
+		"# TYPE v2ray_inbound_connections gauge\n",
+		`v2ray_inbound_connections{inbound="socks"} 2` + "\n",
+		"# TYPE v2ray_outbound_dial_latency_seconds histogram\n",
+		`v2ray_outbound_dial_latency_seconds_bucket{outbound="direct",le="0.1"} 0` + "\n",
+		`v2ray_outbound_dial_latency_seconds_bucket{outbound="direct",le="1"} 1` + "\n",
+		`v2ray_outbound_dial_latency_seconds_bucket{outbound="direct",le="+Inf"} 1` + "\n",
+		`v2ray_outbound_dial_latency_seconds_sum{outbound="direct"} 0.5` + "\n",
+		`v2ray_outbound_dial_latency_seconds_count{outbound="direct"} 1` + "\n",
+	} {
+		if !strings.Contains(body, line) {
+			t.Error("expect ", line, " in output, but got ", body)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// +build !confonly
+
+package stats
+
+import (
+	"sync"
+	"time"
+)
+
+type onlineIP struct {
+	connections int
+	lastSeen    time.Time
+}
+
+// OnlineMap is an implementation of stats.OnlineMap.
+type OnlineMap struct {
+	access  sync.Mutex
+	ips     map[string]*onlineIP
+	timeout time.Duration
+}
+
+// NewOnlineMap creates an OnlineMap where an IP without connections expires after the given timeout.
+func NewOnlineMap(timeout time.Duration) *OnlineMap {
+	return &OnlineMap{
+		ips:     make(map[string]*onlineIP),
+		timeout: timeout,
+	}
+}
+
+// cleanup removes expired IPs. It must be called with m locked.
+func (m *OnlineMap) cleanup(now time.Time) {
+	for ip, entry := range m.ips {
+		if entry.connections <= 0 && now.Sub(entry.lastSeen) > m.timeout {
+			delete(m.ips, ip)
+		}
+	}
+}
+
+// AddIP implements stats.OnlineMap.
+func (m *OnlineMap) AddIP(ip string) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	now := time.Now()
+	m.cleanup(now)
+	entry, found := m.ips[ip]
+	if !found {
+		entry = new(onlineIP)
+		m.ips[ip] = entry
+	}
+	entry.connections++
+	entry.lastSeen = now
+}
+
+// RemoveIP implements stats.OnlineMap.
+func (m *OnlineMap) RemoveIP(ip string) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if entry, found := m.ips[ip]; found {
+		entry.connections--
+		entry.lastSeen = time.Now()
+	}
+}
+
+// Count implements stats.OnlineMap.
+func (m *OnlineMap) Count() int {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	m.cleanup(time.Now())
+	return len(m.ips)
+}
+
+// IPTimeMap implements stats.OnlineMap. IPs with active connections are seen at the current time.
+func (m *OnlineMap) IPTimeMap() map[string]time.Time {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	now := time.Now()
+	m.cleanup(now)
+	ips := make(map[string]time.Time, len(m.ips))
+	for ip, entry := range m.ips {
+		if entry.connections > 0 {
+			ips[ip] = now
+		} else {
+			ips[ip] = entry.lastSeen
+		}
+	}
+	return ips
+}
@@ -0,0 +1,37 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	. "v2ray.com/core/app/stats"
+)
+
+func TestOnlineMap(t *testing.T) {
+	om := NewOnlineMap(time.Millisecond * 100)
+
+	om.AddIP("10.0.0.1")
+	om.AddIP("10.0.0.1")
+	om.AddIP("10.0.0.2")
+	if c := om.Count(); c != 2 {
+		t.Fatal("expect 2 online IPs, but got ", c)
+	}
+
+	om.RemoveIP("10.0.0.1")
+	om.RemoveIP("10.0.0.2")
+	time.Sleep(time.Millisecond * 200)
+
+	ips := om.IPTimeMap()
+	if len(ips) != 1 {
+		t.Fatal("expect 1 online IP, but got ", ips)
+	}
+	if _, found := ips["10.0.0.1"]; !found {
+		t.Error("expect 10.0.0.1 to be online, but got ", ips)
+	}
+
+	om.RemoveIP("10.0.0.1")
+	time.Sleep(time.Millisecond * 200)
+	if c := om.Count(); c != 0 {
+		t.Error("expect no online IP, but got ", c)
+	}
+}
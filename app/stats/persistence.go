@@ -0,0 +1,176 @@
+// +build !confonly
+
+package stats
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"v2ray.com/core/common/strmatcher"
+	"v2ray.com/core/common/task"
+	"v2ray.com/core/features/stats"
+)
+
+// snapshot is the content of the persistence file.
+type snapshot struct {
+	Time     int64            `json:"time"`
+	Counters map[string]int64 `json:"counters"`
+	States   map[string]int64 `json:"states,omitempty"`
+}
+
+// persistence saves counters and states of a Manager to a local file periodically, and restores them on start.
+// Patterns only apply to counters, while all states are persisted.
+type persistence struct {
+	manager  *Manager
+	path     string
+	matchers []strmatcher.Matcher
+	task     *task.Periodic
+}
+
+func newPersistence(m *Manager, config *PersistenceConfig) (*persistence, error) {
+	if len(config.Path) == 0 {
+		return nil, newError("path of stats persistence is not specified")
+	}
+	p := &persistence{
+		manager: m,
+		path:    config.Path,
+	}
+	for _, pattern := range config.Pattern {
+		matcher, err := strmatcher.Substr.New(pattern)
+		if err != nil {
+			return nil, newError("invalid pattern ", pattern).Base(err)
+		}
+		p.matchers = append(p.matchers, matcher)
+	}
+	interval := time.Second * 60
+	if config.Interval > 0 {
+		interval = time.Second * time.Duration(config.Interval)
+	}
+	p.task = &task.Periodic{
+		Interval: interval,
+		Execute: func() error {
+			if err := p.save(); err != nil {
+				newError("failed to save stats to ", p.path).Base(err).AtWarning().WriteToLog()
+			}
+			return nil
+		},
+	}
+	return p, nil
+}
+
+func (p *persistence) shouldPersist(name string) bool {
+	if len(p.matchers) == 0 {
+		return true
+	}
+	for _, matcher := range p.matchers {
+		if matcher.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// restore adds saved values to the counters of the same names, creating them if necessary. States are replaced by
+// saved ones.
+func (p *persistence) restore() error {
+	content, err := ioutil.ReadFile(p.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var s snapshot
+	if err := json.Unmarshal(content, &s); err != nil {
+		return newError("invalid stats file ", p.path).Base(err)
+	}
+
+	m := p.manager
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	restored := 0
+	for name, value := range s.Counters {
+		if !p.shouldPersist(name) {
+			continue
+		}
+		c, found := m.counters[name]
+		if !found {
+			c = new(Counter)
+			m.counters[name] = c
+		}
+		c.Add(value)
+		restored++
+	}
+	for name, value := range s.States {
+		m.states[name] = value
+	}
+	newError("restored ", restored, " counters and ", len(s.States), " states from ", p.path).AtInfo().WriteToLog()
+	return nil
+}
+
+// save writes a snapshot of counters and states into a temporary file, and then renames it to the target path.
+func (p *persistence) save() error {
+	s := snapshot{
+		Time:     time.Now().Unix(),
+		Counters: make(map[string]int64),
+	}
+	p.manager.Visit(func(name string, c stats.Counter) bool {
+		if p.shouldPersist(name) {
+			s.Counters[name] = c.Value()
+		}
+		return true
+	})
+	p.manager.access.RLock()
+	if len(p.manager.states) > 0 {
+		s.States = make(map[string]int64, len(p.manager.states))
+		for name, value := range p.manager.states {
+			s.States[name] = value
+		}
+	}
+	p.manager.access.RUnlock()
+	content, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(p.path), filepath.Base(p.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+	_, err = f.Write(content)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, p.path)
+	}
+	if err != nil {
+		os.Remove(tmpPath) // nolint: errcheck
+		return err
+	}
+	return nil
+}
+
+// Start implements common.Runnable.
+func (p *persistence) Start() error {
+	if err := p.restore(); err != nil {
+		return newError("failed to restore stats from ", p.path).Base(err)
+	}
+	return p.task.Start()
+}
+
+// Close implements common.Closable. It saves a final snapshot.
+func (p *persistence) Close() error {
+	if err := p.task.Close(); err != nil {
+		return err
+	}
+	return p.save()
+}
@@ -0,0 +1,64 @@
+package stats_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "v2ray.com/core/app/stats"
+	"v2ray.com/core/common"
+)
+
+func TestPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "v2ray-stats")
+	common.Must(err)
+	defer os.RemoveAll(dir)
+
+	config := &Config{
+		Persistence: &PersistenceConfig{
+			Path:    filepath.Join(dir, "stats.json"),
+			Pattern: []string{"user>>>"},
+		},
+	}
+
+	m, err := NewManager(context.Background(), config)
+	common.Must(err)
+	common.Must(m.Start())
+	c1, err := m.RegisterCounter("user>>>alice@v2ray.com>>>traffic>>>uplink")
+	common.Must(err)
+	c1.Set(100)
+	c2, err := m.RegisterCounter("inbound>>>socks>>>traffic>>>uplink")
+	common.Must(err)
+	c2.Set(200)
+	m.SetState("user>>>alice@v2ray.com>>>quota>>>used", 300)
+	common.Must(m.Close())
+
+	m, err = NewManager(context.Background(), config)
+	common.Must(err)
+	c1, err = m.RegisterCounter("user>>>alice@v2ray.com>>>traffic>>>uplink")
+	common.Must(err)
+	c1.Set(10)
+	common.Must(m.Start())
+	defer m.Close()
+
+	if v := c1.Value(); v != 110 {
+		t.Error("expect restored value 110, but got ", v)
+	}
+	if c := m.GetCounter("inbound>>>socks>>>traffic>>>uplink"); c != nil {
+		t.Error("expect inbound counter not to be persisted, but got ", c.Value())
+	}
+	if v, found := m.GetState("user>>>alice@v2ray.com>>>quota>>>used"); !found || v != 300 {
+		t.Error("expect restored state 300, but got ", v)
+	}
+	if c := m.GetCounter("user>>>alice@v2ray.com>>>quota>>>used"); c != nil {
+		t.Error("expect state not to be a counter")
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	common.Must(err)
+	if len(files) != 1 {
+		t.Error("expect no temporary file left, but got ", len(files), " files")
+	}
+}
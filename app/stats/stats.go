@@ -8,7 +8,9 @@ import (
 	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"v2ray.com/core/common"
 	"v2ray.com/core/features/stats"
 )
 
@@ -34,13 +36,37 @@ func (c *Counter) Add(delta int64) int64 {
 
 // Manager is an implementation of stats.Manager.
 type Manager struct {
-	access   sync.RWMutex
-	counters map[string]*Counter
+	access      sync.RWMutex
+	counters    map[string]*Counter
+	gauges      map[string]*Gauge
+	histograms  map[string]*Histogram
+	channels    map[string]*Channel
+	online      map[string]*OnlineMap
+	states      map[string]int64
+	config      *Config
+	startTime   time.Time
+	exporter    *exporter
+	persistence *persistence
 }
 
 func NewManager(ctx context.Context, config *Config) (*Manager, error) {
 	m := &Manager{
-		counters: make(map[string]*Counter),
+		counters:   make(map[string]*Counter),
+		gauges:     make(map[string]*Gauge),
+		histograms: make(map[string]*Histogram),
+		channels:   make(map[string]*Channel),
+		online:     make(map[string]*OnlineMap),
+		states:     make(map[string]int64),
+		config:     config,
+		startTime:  time.Now(),
+	}
+
+	if p := config.GetPersistence(); p != nil {
+		persistence, err := newPersistence(m, p)
+		if err != nil {
+			return nil, err
+		}
+		m.persistence = persistence
 	}
 
 	return m, nil
@@ -85,6 +111,36 @@ func (m *Manager) GetCounter(name string) stats.Counter {
 	return nil
 }
 
+// SnapshotCounters returns the values of counters whose names satisfy the filter. If reset is true, the counters are
+// reset in the same pass, during which no counter can be registered or unregistered, and no other snapshot can be taken.
+// Each counter is swapped atomically, so that traffic is counted exactly once among consecutive snapshots.
+func (m *Manager) SnapshotCounters(filter func(string) bool, reset bool) map[string]int64 {
+	if reset {
+		m.access.Lock()
+		defer m.access.Unlock()
+	} else {
+		m.access.RLock()
+		defer m.access.RUnlock()
+	}
+
+	matched := make([]string, 0, len(m.counters))
+	for name := range m.counters {
+		if filter(name) {
+			matched = append(matched, name)
+		}
+	}
+
+	values := make(map[string]int64, len(matched))
+	for _, name := range matched {
+		if reset {
+			values[name] = m.counters[name].Set(0)
+		} else {
+			values[name] = m.counters[name].Value()
+		}
+	}
+	return values
+}
+
 func (m *Manager) Visit(visitor func(string, stats.Counter) bool) {
 	m.access.RLock()
 	defer m.access.RUnlock()
@@ -96,12 +152,238 @@ func (m *Manager) Visit(visitor func(string, stats.Counter) bool) {
 	}
 }
 
+func (m *Manager) RegisterGauge(name string) (stats.Gauge, error) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if _, found := m.gauges[name]; found {
+		return nil, newError("Gauge ", name, " already registered.")
+	}
+	newError("create new gauge ", name).AtDebug().WriteToLog()
+	g := new(Gauge)
+	m.gauges[name] = g
+	return g, nil
+}
+
+func (m *Manager) UnregisterGauge(name string) error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if _, found := m.gauges[name]; !found {
+		return newError("Gauge ", name, " was not found.")
+	}
+	newError("remove gauge ", name).AtDebug().WriteToLog()
+	delete(m.gauges, name)
+	return nil
+}
+
+func (m *Manager) GetGauge(name string) stats.Gauge {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	if g, found := m.gauges[name]; found {
+		return g
+	}
+	return nil
+}
+
+// VisitGauges calls visitor on each gauge, until it returns false.
+func (m *Manager) VisitGauges(visitor func(string, stats.Gauge) bool) {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	for name, g := range m.gauges {
+		if !visitor(name, g) {
+			break
+		}
+	}
+}
+
+func (m *Manager) RegisterHistogram(name string, buckets []float64) (stats.Histogram, error) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if _, found := m.histograms[name]; found {
+		return nil, newError("Histogram ", name, " already registered.")
+	}
+	newError("create new histogram ", name).AtDebug().WriteToLog()
+	h := NewHistogram(buckets)
+	m.histograms[name] = h
+	return h, nil
+}
+
+func (m *Manager) UnregisterHistogram(name string) error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if _, found := m.histograms[name]; !found {
+		return newError("Histogram ", name, " was not found.")
+	}
+	newError("remove histogram ", name).AtDebug().WriteToLog()
+	delete(m.histograms, name)
+	return nil
+}
+
+func (m *Manager) GetHistogram(name string) stats.Histogram {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	if h, found := m.histograms[name]; found {
+		return h
+	}
+	return nil
+}
+
+// VisitHistograms calls visitor on each histogram, until it returns false.
+func (m *Manager) VisitHistograms(visitor func(string, stats.Histogram) bool) {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	for name, h := range m.histograms {
+		if !visitor(name, h) {
+			break
+		}
+	}
+}
+
+func (m *Manager) RegisterChannel(name string) (stats.Channel, error) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if _, found := m.channels[name]; found {
+		return nil, newError("Channel ", name, " already registered.")
+	}
+	newError("create new channel ", name).AtDebug().WriteToLog()
+	c := NewChannel(m.config.GetChannel())
+	m.channels[name] = c
+	return c, nil
+}
+
+func (m *Manager) UnregisterChannel(name string) error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	c, found := m.channels[name]
+	if !found {
+		return newError("Channel ", name, " was not found.")
+	}
+	newError("remove channel ", name).AtDebug().WriteToLog()
+	delete(m.channels, name)
+	return c.Close()
+}
+
+func (m *Manager) GetChannel(name string) stats.Channel {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	if c, found := m.channels[name]; found {
+		return c
+	}
+	return nil
+}
+
+func (m *Manager) RegisterOnlineMap(name string) (stats.OnlineMap, error) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if _, found := m.online[name]; found {
+		return nil, newError("OnlineMap ", name, " already registered.")
+	}
+	newError("create new online map ", name).AtDebug().WriteToLog()
+	timeout := time.Second * 60
+	if t := m.config.GetOnlineTimeout(); t > 0 {
+		timeout = time.Second * time.Duration(t)
+	}
+	om := NewOnlineMap(timeout)
+	m.online[name] = om
+	return om, nil
+}
+
+func (m *Manager) UnregisterOnlineMap(name string) error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if _, found := m.online[name]; !found {
+		return newError("OnlineMap ", name, " was not found.")
+	}
+	newError("remove online map ", name).AtDebug().WriteToLog()
+	delete(m.online, name)
+	return nil
+}
+
+func (m *Manager) GetOnlineMap(name string) stats.OnlineMap {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	if om, found := m.online[name]; found {
+		return om
+	}
+	return nil
+}
+
+// VisitOnlineMaps calls visitor on each online map, until it returns false.
+func (m *Manager) VisitOnlineMaps(visitor func(string, stats.OnlineMap) bool) {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	for name, om := range m.online {
+		if !visitor(name, om) {
+			break
+		}
+	}
+}
+
+// GetState implements stats.StateManager.
+func (m *Manager) GetState(name string) (int64, bool) {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	value, found := m.states[name]
+	return value, found
+}
+
+// SetState implements stats.StateManager.
+func (m *Manager) SetState(name string, value int64) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	m.states[name] = value
+}
+
 // Start implements common.Runnable.
 func (m *Manager) Start() error {
+	if m.persistence != nil {
+		if err := m.persistence.Start(); err != nil {
+			return err
+		}
+	}
+	if m.config != nil && m.config.Exporter != nil {
+		e, err := newExporter(m.config.Exporter, m)
+		if err != nil {
+			return err
+		}
+		m.exporter = e
+	}
 	return nil
 }
 
 // Close implement common.Closable.
 func (m *Manager) Close() error {
+	if m.persistence != nil {
+		if err := m.persistence.Close(); err != nil {
+			newError("failed to save stats").Base(err).AtWarning().WriteToLog()
+		}
+	}
+
+	m.access.Lock()
+	for name, c := range m.channels {
+		common.Must(c.Close())
+		delete(m.channels, name)
+	}
+	m.access.Unlock()
+
+	if m.exporter != nil {
+		return m.exporter.Close()
+	}
 	return nil
 }
@@ -2,6 +2,8 @@ package stats_test
 
 import (
 	"context"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	. "v2ray.com/core/app/stats"
@@ -33,3 +35,34 @@ func TestStatsCounter(t *testing.T) {
 		t.Fatal("unexpected Value() return: ", v, ", wanted ", 0)
 	}
 }
+
+func TestExporter(t *testing.T) {
+	m, err := NewManager(context.Background(), &Config{})
+	common.Must(err)
+
+	for name, value := range map[string]int64{
+		"user>>>alice@v2ray.com>>>traffic>>>uplink": 100,
+		"inbound>>>socks>>>traffic>>>downlink":      200,
+		"custom.counter":                            300,
+	} {
+		c, err := m.RegisterCounter(name)
+		common.Must(err)
+		c.Set(value)
+	}
+
+	recorder := httptest.NewRecorder()
+	m.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+
+	for _, line := range []string{
+		"# TYPE v2ray_user_traffic_bytes_total counter\n",
+		`v2ray_user_traffic_bytes_total{user="alice@v2ray.com",direction="uplink"} 100` + "\n",
+		`v2ray_inbound_traffic_bytes_total{inbound="socks",direction="downlink"} 200` + "\n",
+		`v2ray_counter{name="custom.counter"} 300` + "\n",
+		"# TYPE v2ray_goroutines gauge\n",
+	} {
+		if !strings.Contains(body, line) {
+			t.Error("expect ", line, " in output, but got ", body)
+		}
+	}
+}
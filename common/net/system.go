@@ -49,6 +49,7 @@ const IPv6len = net.IPv6len
 
 type Error = net.Error
 type AddrError = net.AddrError
+type DNSError = net.DNSError
 
 type Dialer = net.Dialer
 type Listener = net.Listener
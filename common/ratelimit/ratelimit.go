@@ -0,0 +1,98 @@
+// Package ratelimit provides a token bucket to limit bandwidth of connections.
+package ratelimit
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/signal/done"
+)
+
+// Limiter is a token bucket that allows bytes at a given rate, with bursts up to a given size.
+// It may be shared by multiple connections.
+type Limiter struct {
+	sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// New creates a Limiter with the given rate in bytes per second, and the given burst in bytes.
+// burst is set to rate if it is 0.
+func New(rate uint64, burst uint64) *Limiter {
+	if burst == 0 {
+		burst = rate
+	}
+	return &Limiter{
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Reserve takes n bytes from the bucket, and returns how long the caller needs to wait before sending them.
+func (l *Limiter) Reserve(n int64) time.Duration {
+	l.Lock()
+	defer l.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// Writer is a buf.Writer that writes no faster than its Limiter allows.
+type Writer struct {
+	limiter *Limiter
+	writer  buf.Writer
+	done    *done.Instance
+}
+
+// NewWriter creates a Writer that limits the given writer by the given limiter.
+func NewWriter(writer buf.Writer, limiter *Limiter) *Writer {
+	return &Writer{
+		limiter: limiter,
+		writer:  writer,
+		done:    done.New(),
+	}
+}
+
+// WriteMultiBuffer implements buf.Writer.
+func (w *Writer) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	if d := w.limiter.Reserve(int64(mb.Len())); d > 0 {
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+		case <-w.done.Wait():
+			timer.Stop()
+			buf.ReleaseMulti(mb)
+			return io.ErrClosedPipe
+		}
+	}
+	return w.writer.WriteMultiBuffer(mb)
+}
+
+// Close implements common.Closable.
+func (w *Writer) Close() error {
+	common.Must(w.done.Close())
+	return common.Close(w.writer)
+}
+
+// Interrupt implements common.Interruptible.
+func (w *Writer) Interrupt() {
+	common.Must(w.done.Close())
+	common.Interrupt(w.writer)
+}
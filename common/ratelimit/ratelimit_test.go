@@ -0,0 +1,37 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
+	. "v2ray.com/core/common/ratelimit"
+)
+
+func TestLimiterReserve(t *testing.T) {
+	l := New(1000, 500)
+
+	if d := l.Reserve(500); d != 0 {
+		t.Error("expect burst to be available immediately, but need to wait ", d)
+	}
+	if d := l.Reserve(500); d < 400*time.Millisecond || d > 500*time.Millisecond {
+		t.Error("expect to wait about 500ms, but actually ", d)
+	}
+}
+
+func TestWriterInterrupt(t *testing.T) {
+	l := New(1, 1)
+	w := NewWriter(buf.Discard, l)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		w.Interrupt()
+	}()
+
+	b := buf.New()
+	common.Must2(b.WriteString("v2ray"))
+	if err := w.WriteMultiBuffer(buf.MultiBuffer{b}); err == nil {
+		t.Error("expect error on interrupted writer")
+	}
+}
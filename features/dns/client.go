@@ -1,6 +1,9 @@
 package dns
 
 import (
+	"context"
+
+	"golang.org/x/net/dns/dnsmessage"
 	"v2ray.com/core/common/errors"
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/common/serial"
@@ -31,6 +34,36 @@ type IPv6Lookup interface {
 	LookupIPv6(domain string) ([]net.IP, error)
 }
 
+// RecordLookup is an optional feature for querying DNS records of any type, along with their TTLs.
+//
+// v2ray:api:beta
+type RecordLookup interface {
+	// LookupRecords returns answers of the given type for the domain. TTLs in answers are the remaining ones.
+	// The context may carry the inbound of the client that sends the query.
+	LookupRecords(ctx context.Context, domain string, qType dnsmessage.Type) ([]dnsmessage.Resource, error)
+}
+
+type dnsKey int
+
+const inboundRoutingKey dnsKey = 0
+
+// ContextWithInboundRouting returns a context in which queries sent to upstream servers for a lookup are routed as
+// connections of the inbound in the context, rather than those of the DNS client itself.
+//
+// v2ray:api:beta
+func ContextWithInboundRouting(ctx context.Context) context.Context {
+	return context.WithValue(ctx, inboundRoutingKey, true)
+}
+
+// InboundRoutingFromContext returns true if queries for lookups with the context are routed as connections of its
+// inbound.
+//
+// v2ray:api:beta
+func InboundRoutingFromContext(ctx context.Context) bool {
+	routed, _ := ctx.Value(inboundRoutingKey).(bool)
+	return routed
+}
+
 // ClientType returns the type of Client interface. Can be used for implementing common.HasType.
 //
 // v2ray:api:beta
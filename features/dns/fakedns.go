@@ -0,0 +1,27 @@
+package dns
+
+import (
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/features"
+)
+
+// FakeDNSEngine is a V2Ray feature that hands out synthetic IPs for domains, and maps them back.
+//
+// v2ray:api:beta
+type FakeDNSEngine interface {
+	features.Feature
+
+	// GetFakeIPForDomain returns the fake IPs assigned to the domain, assigning new ones if necessary.
+	GetFakeIPForDomain(domain string) []net.Address
+	// GetDomainFromFakeDNS returns the domain that the fake IP is assigned to, or empty if not found.
+	GetDomainFromFakeDNS(ip net.Address) string
+	// IsIPInIPPool returns true if the IP is in the pool of fake IPs.
+	IsIPInIPPool(ip net.Address) bool
+}
+
+// FakeDNSEngineType returns the type of FakeDNSEngine interface. Can be used for implementing common.HasType.
+//
+// v2ray:api:beta
+func FakeDNSEngineType() interface{} {
+	return (*FakeDNSEngine)(nil)
+}
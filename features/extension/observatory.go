@@ -0,0 +1,22 @@
+package extension
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+
+	"v2ray.com/core/features"
+)
+
+// Observatory is a feature that keeps track of the health of outbound handlers.
+type Observatory interface {
+	features.Feature
+
+	// GetObservation returns the latest observation result. The concrete message type depends on the implementation.
+	GetObservation(ctx context.Context) (proto.Message, error)
+}
+
+// ObservatoryType returns the type of Observatory interface. Can be used to implement common.HasType.
+func ObservatoryType() interface{} {
+	return (*Observatory)(nil)
+}
@@ -21,6 +21,12 @@ type HandlerSelector interface {
 	Select([]string) []string
 }
 
+// LoadReporter is an optional interface for Handlers that keep track of their active connections.
+type LoadReporter interface {
+	// ActiveConnections returns the number of connections currently being handled.
+	ActiveConnections() int64
+}
+
 // Manager is a feature that manages outbound.Handlers.
 //
 // v2ray:api:stable
@@ -27,6 +27,8 @@ type Stats struct {
 	UserUplink bool
 	// Whether or not to enable stat counter for user downlink traffic.
 	UserDownlink bool
+	// Whether or not to track source IPs of online users.
+	UserOnline bool
 }
 
 // Buffer contains settings for internal buffer.
@@ -35,6 +37,26 @@ type Buffer struct {
 	PerConnection int32
 }
 
+// RateLimit contains settings for bandwidth limit.
+type RateLimit struct {
+	// Bandwidth of uplink traffic in bytes per second. 0 for unlimited.
+	Uplink uint64
+	// Bandwidth of downlink traffic in bytes per second. 0 for unlimited.
+	Downlink uint64
+	// Maximum bytes of uplink traffic that can be sent at once.
+	UplinkBurst uint64
+	// Maximum bytes of downlink traffic that can be sent at once.
+	DownlinkBurst uint64
+}
+
+// UserLimit contains limits of concurrent usage of a user.
+type UserLimit struct {
+	// Maximum number of simultaneous connections. 0 for unlimited.
+	MaxConnections uint32
+	// Maximum number of distinct source IPs of simultaneous connections. 0 for unlimited.
+	MaxSourceIPs uint32
+}
+
 // SystemStats contains stat policy settings on system level.
 type SystemStats struct {
 	// Whether or not to enable stat counter for uplink traffic in inbound handlers.
@@ -51,13 +73,17 @@ type SystemStats struct {
 type System struct {
 	Stats  SystemStats
 	Buffer Buffer
+	// Bandwidth limits of inbounds, keyed by inbound tag.
+	InboundRateLimit map[string]RateLimit
 }
 
 // Session is session based settings for controlling V2Ray requests. It contains various settings (or limits) that may differ for different users in the context.
 type Session struct {
-	Timeouts Timeout // Timeout settings
-	Stats    Stats
-	Buffer   Buffer
+	Timeouts  Timeout // Timeout settings
+	Stats     Stats
+	Buffer    Buffer
+	RateLimit RateLimit
+	UserLimit UserLimit
 }
 
 // Manager is a feature that provides Policy for the given user by its id or level.
@@ -1,8 +1,6 @@
 package routing
 
 import (
-	"context"
-
 	"v2ray.com/core/common"
 	"v2ray.com/core/features"
 )
@@ -13,8 +11,25 @@ import (
 type Router interface {
 	features.Feature
 
-	// PickRoute returns a tag of an OutboundHandler based on the given context.
-	PickRoute(ctx context.Context) (string, error)
+	// PickRoute returns a route decision based on the given routing context.
+	PickRoute(ctx Context) (Route, error)
+}
+
+// Route is the routing result of Router feature.
+//
+// v2ray:api:beta
+type Route interface {
+	// A Route is also a routing context.
+	Context
+
+	// GetOutboundTag returns the tag of the outbound the connection was dispatched to.
+	GetOutboundTag() string
+
+	// GetBalancerTag returns the tag of the balancer that picked the outbound, if any.
+	GetBalancerTag() string
+
+	// GetRuleTag returns the tag of the matched rule, if any.
+	GetRuleTag() string
 }
 
 // RouterType return the type of Router interface. Can be used to implement common.HasType.
@@ -33,8 +48,8 @@ func (DefaultRouter) Type() interface{} {
 }
 
 // PickRoute implements Router.
-func (DefaultRouter) PickRoute(ctx context.Context) (string, error) {
-	return "", common.ErrNoClue
+func (DefaultRouter) PickRoute(ctx Context) (Route, error) {
+	return nil, common.ErrNoClue
 }
 
 // Start implements common.Runnable.
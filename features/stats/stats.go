@@ -2,7 +2,12 @@ package stats
 
 //go:generate errorgen
 
-import "v2ray.com/core/features"
+import (
+	"time"
+
+	"v2ray.com/core/common"
+	"v2ray.com/core/features"
+)
 
 // Counter is the interface for stats counters.
 //
@@ -16,6 +21,70 @@ type Counter interface {
 	Add(int64) int64
 }
 
+// Gauge is the interface for stats gauges. Unlike Counter, its value goes up and down, such as the number of active connections.
+//
+// v2ray:api:beta
+type Gauge interface {
+	// Value is the current value of the gauge.
+	Value() int64
+	// Set sets a new value to the gauge, and returns the previous one.
+	Set(int64) int64
+	// Add adds a value, which may be negative, to the gauge, and returns the new value.
+	Add(int64) int64
+}
+
+// HistogramSnapshot is the state of a Histogram at a point in time.
+type HistogramSnapshot struct {
+	// Buckets are the upper bounds of buckets in increasing order. The bucket of +Inf is implicit.
+	Buckets []float64
+	// Counts are the cumulative numbers of observed values less than or equal to each bucket bound.
+	Counts []uint64
+	// Count is the number of all observed values, and Sum is their sum.
+	Count uint64
+	Sum   float64
+}
+
+// Histogram is the interface for stats histograms, which count observed values in buckets.
+//
+// v2ray:api:beta
+type Histogram interface {
+	// Observe adds a value to the histogram.
+	Observe(float64)
+	// Snapshot returns the current state of the histogram.
+	Snapshot() HistogramSnapshot
+}
+
+// Channel is the interface for stats channel. Messages published to a channel are delivered to all its subscribers.
+//
+// v2ray:api:beta
+type Channel interface {
+	// Channel is a runnable unit.
+	common.Runnable
+	// Publish broadcasts a message through the channel.
+	Publish(interface{})
+	// Subscribe registers a new subscriber and returns the go channel that receives the messages.
+	Subscribe() (chan interface{}, error)
+	// Unsubscribe unregisters a subscriber returned by Subscribe.
+	Unsubscribe(chan interface{}) error
+	// Subscribers returns the number of current subscribers.
+	Subscribers() int
+}
+
+// OnlineMap is the interface for tracking source IPs of a user. An IP is online while it has active
+// connections, and it expires after being idle for a while.
+//
+// v2ray:api:beta
+type OnlineMap interface {
+	// AddIP records a new connection from the given IP.
+	AddIP(string)
+	// RemoveIP records the end of a connection from the given IP.
+	RemoveIP(string)
+	// Count returns the number of online IPs.
+	Count() int
+	// IPTimeMap returns online IPs along with their last seen time.
+	IPTimeMap() map[string]time.Time
+}
+
 // Manager is the interface for stats manager.
 //
 // v2ray:api:stable
@@ -29,6 +98,61 @@ type Manager interface {
 	GetCounter(string) Counter
 }
 
+// GaugeManager is an optional feature of Manager that manages gauges.
+//
+// v2ray:api:beta
+type GaugeManager interface {
+	// RegisterGauge registers a new gauge to the manager. The identifier string must not be empty, and unique among other gauges.
+	RegisterGauge(string) (Gauge, error)
+	UnregisterGauge(string) error
+	// GetGauge returns a gauge by its identifier.
+	GetGauge(string) Gauge
+}
+
+// HistogramManager is an optional feature of Manager that manages histograms.
+//
+// v2ray:api:beta
+type HistogramManager interface {
+	// RegisterHistogram registers a new histogram with the given bucket bounds to the manager. The identifier string must not be empty, and unique among other histograms.
+	RegisterHistogram(string, []float64) (Histogram, error)
+	UnregisterHistogram(string) error
+	// GetHistogram returns a histogram by its identifier.
+	GetHistogram(string) Histogram
+}
+
+// ChannelManager is an optional feature of Manager that manages channels.
+//
+// v2ray:api:beta
+type ChannelManager interface {
+	// RegisterChannel registers a new channel to the manager. The identifier string must not be empty, and unique among other channels.
+	RegisterChannel(string) (Channel, error)
+	UnregisterChannel(string) error
+	// GetChannel returns a channel by its identifier.
+	GetChannel(string) Channel
+}
+
+// OnlineMapManager is an optional feature of Manager that manages online maps.
+//
+// v2ray:api:beta
+type OnlineMapManager interface {
+	// RegisterOnlineMap registers a new online map to the manager. The identifier string must not be empty, and unique among other online maps.
+	RegisterOnlineMap(string) (OnlineMap, error)
+	UnregisterOnlineMap(string) error
+	// GetOnlineMap returns an online map by its identifier.
+	GetOnlineMap(string) OnlineMap
+}
+
+// StateManager is an optional feature of Manager that keeps named values along with stats, such as in persisted
+// snapshots. Unlike counters, the values are neither listed nor reset by stats queries.
+//
+// v2ray:api:beta
+type StateManager interface {
+	// GetState returns the value of the given name, and whether it exists.
+	GetState(string) (int64, bool)
+	// SetState sets the value of the given name.
+	SetState(string, int64)
+}
+
 // GetOrRegisterCounter tries to get the StatCounter first. If not exist, it then tries to create a new counter.
 func GetOrRegisterCounter(m Manager, name string) (Counter, error) {
 	counter := m.GetCounter(name)
@@ -39,6 +163,66 @@ func GetOrRegisterCounter(m Manager, name string) (Counter, error) {
 	return m.RegisterCounter(name)
 }
 
+// GetOrRegisterGauge tries to get the Gauge first. If not exist, it then tries to create a new gauge. It fails if the
+// Manager is not a GaugeManager.
+func GetOrRegisterGauge(m Manager, name string) (Gauge, error) {
+	mm, ok := m.(GaugeManager)
+	if !ok {
+		return nil, newError("gauge is not supported by the stats manager")
+	}
+	gauge := mm.GetGauge(name)
+	if gauge != nil {
+		return gauge, nil
+	}
+
+	return mm.RegisterGauge(name)
+}
+
+// GetOrRegisterHistogram tries to get the Histogram first. If not exist, it then tries to create a new histogram with the given buckets.
+// It fails if the Manager is not a HistogramManager.
+func GetOrRegisterHistogram(m Manager, name string, buckets []float64) (Histogram, error) {
+	mm, ok := m.(HistogramManager)
+	if !ok {
+		return nil, newError("histogram is not supported by the stats manager")
+	}
+	histogram := mm.GetHistogram(name)
+	if histogram != nil {
+		return histogram, nil
+	}
+
+	return mm.RegisterHistogram(name, buckets)
+}
+
+// GetOrRegisterChannel tries to get the StatChannel first. If not exist, it then tries to create a new channel. It fails if
+// the Manager is not a ChannelManager.
+func GetOrRegisterChannel(m Manager, name string) (Channel, error) {
+	mm, ok := m.(ChannelManager)
+	if !ok {
+		return nil, newError("channel is not supported by the stats manager")
+	}
+	channel := mm.GetChannel(name)
+	if channel != nil {
+		return channel, nil
+	}
+
+	return mm.RegisterChannel(name)
+}
+
+// GetOrRegisterOnlineMap tries to get the OnlineMap first. If not exist, it then tries to create a new online map. It fails
+// if the Manager is not an OnlineMapManager.
+func GetOrRegisterOnlineMap(m Manager, name string) (OnlineMap, error) {
+	mm, ok := m.(OnlineMapManager)
+	if !ok {
+		return nil, newError("online map is not supported by the stats manager")
+	}
+	onlineMap := mm.GetOnlineMap(name)
+	if onlineMap != nil {
+		return onlineMap, nil
+	}
+
+	return mm.RegisterOnlineMap(name)
+}
+
 // ManagerType returns the type of Manager interface. Can be used to implement common.HasType.
 //
 // v2ray:api:stable
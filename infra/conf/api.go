@@ -4,8 +4,11 @@ import (
 	"strings"
 
 	"v2ray.com/core/app/commander"
+	dispatcherservice "v2ray.com/core/app/dispatcher/command"
 	loggerservice "v2ray.com/core/app/log/command"
+	observatoryservice "v2ray.com/core/app/observatory/command"
 	handlerservice "v2ray.com/core/app/proxyman/command"
+	routingservice "v2ray.com/core/app/router/command"
 	statsservice "v2ray.com/core/app/stats/command"
 	"v2ray.com/core/common/serial"
 )
@@ -29,6 +32,12 @@ func (c *ApiConfig) Build() (*commander.Config, error) {
 			services = append(services, serial.ToTypedMessage(&loggerservice.Config{}))
 		case "statsservice":
 			services = append(services, serial.ToTypedMessage(&statsservice.Config{}))
+		case "observatoryservice":
+			services = append(services, serial.ToTypedMessage(&observatoryservice.Config{}))
+		case "routingservice":
+			services = append(services, serial.ToTypedMessage(&routingservice.Config{}))
+		case "dispatcherservice":
+			services = append(services, serial.ToTypedMessage(&dispatcherservice.Config{}))
 		}
 	}
 
@@ -3,18 +3,23 @@ package conf
 import (
 	"encoding/json"
 	"sort"
+	"strconv"
 	"strings"
 
+	"golang.org/x/net/dns/dnsmessage"
 	"v2ray.com/core/app/dns"
 	"v2ray.com/core/app/router"
 	"v2ray.com/core/common/net"
 )
 
 type NameServerConfig struct {
-	Address   *Address
-	Port      uint16
-	Domains   []string
-	ExpectIPs StringList
+	Address       *Address
+	Port          uint16
+	Domains       []string
+	ExpectIPs     StringList
+	DisableCache  bool
+	QueryStrategy string
+	Tag           string
 }
 
 func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
@@ -25,16 +30,22 @@ func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
 	}
 
 	var advanced struct {
-		Address   *Address   `json:"address"`
-		Port      uint16     `json:"port"`
-		Domains   []string   `json:"domains"`
-		ExpectIPs StringList `json:"expectIps"`
+		Address       *Address   `json:"address"`
+		Port          uint16     `json:"port"`
+		Domains       []string   `json:"domains"`
+		ExpectIPs     StringList `json:"expectIps"`
+		DisableCache  bool       `json:"disableCache"`
+		QueryStrategy string     `json:"queryStrategy"`
+		Tag           string     `json:"tag"`
 	}
 	if err := json.Unmarshal(data, &advanced); err == nil {
 		c.Address = advanced.Address
 		c.Port = advanced.Port
 		c.Domains = advanced.Domains
 		c.ExpectIPs = advanced.ExpectIPs
+		c.DisableCache = advanced.DisableCache
+		c.QueryStrategy = advanced.QueryStrategy
+		c.Tag = advanced.Tag
 		return nil
 	}
 
@@ -56,10 +67,30 @@ func toDomainMatchingType(t router.Domain_Type) dns.DomainMatchingType {
 	}
 }
 
+func parseQueryStrategy(s string) (dns.QueryStrategy, error) {
+	switch strings.ToLower(s) {
+	case "", "useip":
+		return dns.QueryStrategy_USE_IP, nil
+	case "useipv4":
+		return dns.QueryStrategy_USE_IP4, nil
+	case "useipv6":
+		return dns.QueryStrategy_USE_IP6, nil
+	case "preferipv4":
+		return dns.QueryStrategy_PREFER_IP4, nil
+	case "preferipv6":
+		return dns.QueryStrategy_PREFER_IP6, nil
+	default:
+		return dns.QueryStrategy_USE_IP, newError("unknown query strategy: ", s)
+	}
+}
+
 func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 	if c.Address == nil {
 		return nil, newError("NameServer address is not specified.")
 	}
+	if addr := c.Address.Address; addr.Family().IsDomain() && (strings.HasPrefix(addr.Domain(), "quic://") || strings.HasPrefix(addr.Domain(), "quic+local://")) {
+		return nil, newError("DNS over QUIC is not supported: ", addr.Domain())
+	}
 
 	var domains []*dns.NameServer_PriorityDomain
 
@@ -82,6 +113,11 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 		return nil, newError("invalid ip rule: ", c.ExpectIPs).Base(err)
 	}
 
+	strategy, err := parseQueryStrategy(c.QueryStrategy)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dns.NameServer{
 		Address: &net.Endpoint{
 			Network: net.Network_UDP,
@@ -90,9 +126,94 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 		},
 		PrioritizedDomain: domains,
 		Geoip:             geoipList,
+		DisableCache:      c.DisableCache,
+		QueryStrategy:     strategy,
+		Tag:               c.Tag,
 	}, nil
 }
 
+var queryTypeMap = map[string]dnsmessage.Type{
+	"a":     dnsmessage.TypeA,
+	"ns":    dnsmessage.TypeNS,
+	"cname": dnsmessage.TypeCNAME,
+	"soa":   dnsmessage.TypeSOA,
+	"ptr":   dnsmessage.TypePTR,
+	"mx":    dnsmessage.TypeMX,
+	"txt":   dnsmessage.TypeTXT,
+	"aaaa":  dnsmessage.TypeAAAA,
+	"srv":   dnsmessage.TypeSRV,
+	"any":   dnsmessage.TypeALL,
+}
+
+func parseQueryType(s string) (uint32, error) {
+	if t, found := queryTypeMap[strings.ToLower(s)]; found {
+		return uint32(t), nil
+	}
+	t, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, newError("unknown query type: ", s)
+	}
+	return uint32(t), nil
+}
+
+// DnsRuleConfig is a JSON serializable object for dns.QueryRule.
+type DnsRuleConfig struct {
+	Domain     StringList `json:"domain"`
+	QueryType  StringList `json:"queryType"`
+	Source     StringList `json:"source"`
+	InboundTag StringList `json:"inboundTag"`
+	Server     string     `json:"server"`
+	Action     string     `json:"action"`
+}
+
+// Build implements Buildable
+func (c *DnsRuleConfig) Build() (*dns.QueryRule, error) {
+	rule := new(dns.QueryRule)
+
+	switch strings.ToLower(c.Action) {
+	case "", "route":
+		rule.Action = dns.QueryAction_ROUTE
+		rule.ServerTag = c.Server
+	case "nxdomain":
+		rule.Action = dns.QueryAction_NXDOMAIN
+	case "zeroip":
+		rule.Action = dns.QueryAction_ZERO_IP
+	default:
+		return nil, newError("unknown action of DNS rule: ", c.Action)
+	}
+	if rule.Action != dns.QueryAction_ROUTE && len(c.Server) > 0 {
+		return nil, newError("server of DNS rule is only for action route")
+	}
+
+	for _, d := range c.Domain {
+		domains, err := parseDomainRule(d)
+		if err != nil {
+			return nil, newError("invalid domain rule: ", d).Base(err)
+		}
+		rule.Domain = append(rule.Domain, domains...)
+	}
+
+	for _, t := range c.QueryType {
+		qType, err := parseQueryType(t)
+		if err != nil {
+			return nil, err
+		}
+		rule.QueryType = append(rule.QueryType, qType)
+	}
+
+	if len(c.Source) > 0 {
+		geoipList, err := toCidrList(c.Source)
+		if err != nil {
+			return nil, newError("invalid source rule: ", c.Source).Base(err)
+		}
+		rule.SourceGeoip = geoipList
+	}
+
+	rule.InboundTag = c.InboundTag
+
+	return rule, nil
+}
+
 var typeMap = map[router.Domain_Type]dns.DomainMatchingType{
 	router.Domain_Full:   dns.DomainMatchingType_Full,
 	router.Domain_Domain: dns.DomainMatchingType_Subdomain,
@@ -100,12 +221,74 @@ var typeMap = map[router.Domain_Type]dns.DomainMatchingType{
 	router.Domain_Regex:  dns.DomainMatchingType_Regex,
 }
 
+// DnsCacheConfig is a JSON serializable object for dns.CacheConfig.
+type DnsCacheConfig struct {
+	Size       uint32 `json:"size"`
+	MinTTL     uint32 `json:"minTTL"`
+	MaxTTL     uint32 `json:"maxTTL"`
+	ServeStale bool   `json:"serveStale"`
+	StaleTTL   uint32 `json:"staleTTL"`
+}
+
+// Build implements Buildable
+func (c *DnsCacheConfig) Build() (*dns.CacheConfig, error) {
+	if c.MinTTL > 0 && c.MaxTTL > 0 && c.MinTTL > c.MaxTTL {
+		return nil, newError("minTTL of DNS cache is larger than maxTTL")
+	}
+	return &dns.CacheConfig{
+		Size:       c.Size,
+		MinTtl:     c.MinTTL,
+		MaxTtl:     c.MaxTTL,
+		ServeStale: c.ServeStale,
+		StaleTtl:   c.StaleTTL,
+	}, nil
+}
+
+// DnsHostsFileConfig is a JSON serializable object for dns.HostsFile.
+type DnsHostsFileConfig struct {
+	Path    string   `json:"path"`
+	Format  string   `json:"format"`
+	Address *Address `json:"address"`
+}
+
+// Build implements Buildable
+func (c *DnsHostsFileConfig) Build() (*dns.HostsFile, error) {
+	if len(c.Path) == 0 {
+		return nil, newError("path of hosts file is not specified")
+	}
+	file := &dns.HostsFile{
+		Path: c.Path,
+	}
+
+	switch strings.ToLower(c.Format) {
+	case "", "hosts":
+		file.Format = dns.HostsFile_HOSTS
+	case "domainlist":
+		file.Format = dns.HostsFile_DOMAIN_LIST
+		if c.Address == nil {
+			return nil, newError("address of domain list is not specified: ", c.Path)
+		}
+		mapping := getHostMapping(c.Address)
+		file.Ip = mapping.Ip
+		file.ProxiedDomain = mapping.ProxiedDomain
+	default:
+		return nil, newError("unknown format of hosts file: ", c.Format)
+	}
+	return file, nil
+}
+
 // DnsConfig is a JSON serializable object for dns.Config.
 type DnsConfig struct {
-	Servers  []*NameServerConfig `json:"servers"`
-	Hosts    map[string]*Address `json:"hosts"`
-	ClientIP *Address            `json:"clientIp"`
-	Tag      string              `json:"tag"`
+	Servers                []*NameServerConfig   `json:"servers"`
+	Hosts                  map[string]*Address   `json:"hosts"`
+	ClientIP               *Address              `json:"clientIp"`
+	Tag                    string                `json:"tag"`
+	Cache                  *DnsCacheConfig       `json:"cache"`
+	DisableCache           bool                  `json:"disableCache"`
+	QueryStrategy          string                `json:"queryStrategy"`
+	Rules                  []*DnsRuleConfig      `json:"rules"`
+	HostsFiles             []*DnsHostsFileConfig `json:"hostsFiles"`
+	HostsFileCheckInterval uint32                `json:"hostsFileCheckInterval"`
 }
 
 func getHostMapping(addr *Address) *dns.Config_HostMapping {
@@ -123,7 +306,23 @@ func getHostMapping(addr *Address) *dns.Config_HostMapping {
 // Build implements Buildable
 func (c *DnsConfig) Build() (*dns.Config, error) {
 	config := &dns.Config{
-		Tag: c.Tag,
+		Tag:                    c.Tag,
+		DisableCache:           c.DisableCache,
+		HostsFileCheckInterval: c.HostsFileCheckInterval,
+	}
+
+	strategy, err := parseQueryStrategy(c.QueryStrategy)
+	if err != nil {
+		return nil, err
+	}
+	config.QueryStrategy = strategy
+
+	if c.Cache != nil {
+		cache, err := c.Cache.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.Cache = cache
 	}
 
 	if c.ClientIP != nil {
@@ -141,6 +340,22 @@ func (c *DnsConfig) Build() (*dns.Config, error) {
 		config.NameServer = append(config.NameServer, ns)
 	}
 
+	for _, rule := range c.Rules {
+		r, err := rule.Build()
+		if err != nil {
+			return nil, newError("failed to build DNS rule").Base(err)
+		}
+		config.Rule = append(config.Rule, r)
+	}
+
+	for _, file := range c.HostsFiles {
+		f, err := file.Build()
+		if err != nil {
+			return nil, newError("failed to build hosts file").Base(err)
+		}
+		config.HostsFile = append(config.HostsFile, f)
+	}
+
 	if c.Hosts != nil && len(c.Hosts) > 0 {
 		domains := make([]string, 0, len(c.Hosts))
 		for domain := range c.Hosts {
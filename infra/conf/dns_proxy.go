@@ -1,15 +1,19 @@
 package conf
 
 import (
+	"strings"
+
 	"github.com/golang/protobuf/proto"
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/proxy/dns"
 )
 
 type DnsOutboundConfig struct {
-	Network Network  `json:"network"`
-	Address *Address `json:"address"`
-	Port    uint16   `json:"port"`
+	Network          Network  `json:"network"`
+	Address          *Address `json:"address"`
+	Port             uint16   `json:"port"`
+	FullServer       bool     `json:"fullServer"`
+	UnsupportedQuery string   `json:"unsupportedQuery"`
 }
 
 func (c *DnsOutboundConfig) Build() (proto.Message, error) {
@@ -18,9 +22,51 @@ func (c *DnsOutboundConfig) Build() (proto.Message, error) {
 			Network: c.Network.Build(),
 			Port:    uint32(c.Port),
 		},
+		FullServer: c.FullServer,
 	}
 	if c.Address != nil {
 		config.Server.Address = c.Address.Build()
 	}
+	action, err := parseUnsupportedQueryAction(c.UnsupportedQuery)
+	if err != nil {
+		return nil, err
+	}
+	config.UnsupportedQueryAction = action
 	return config, nil
 }
+
+func parseUnsupportedQueryAction(s string) (dns.UnsupportedQueryAction, error) {
+	switch strings.ToLower(s) {
+	case "", "forward":
+		return dns.UnsupportedQueryAction_FORWARD, nil
+	case "reject":
+		return dns.UnsupportedQueryAction_REJECT, nil
+	case "empty":
+		return dns.UnsupportedQueryAction_EMPTY, nil
+	default:
+		return dns.UnsupportedQueryAction_FORWARD, newError("unknown action for unsupported queries: ", s)
+	}
+}
+
+type DnsInboundConfig struct {
+	FullServer       bool   `json:"fullServer"`
+	UnsupportedQuery string `json:"unsupportedQuery"`
+	DohPath          string `json:"dohPath"`
+	UserLevel        uint32 `json:"userLevel"`
+}
+
+func (c *DnsInboundConfig) Build() (proto.Message, error) {
+	action, err := parseUnsupportedQueryAction(c.UnsupportedQuery)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.DohPath) > 0 && !strings.HasPrefix(c.DohPath, "/") {
+		return nil, newError("DoH path must start with '/': ", c.DohPath)
+	}
+	return &dns.ServerConfig{
+		FullServer:             c.FullServer,
+		UnsupportedQueryAction: action,
+		DohPath:                c.DohPath,
+		UserLevel:              c.UserLevel,
+	}, nil
+}
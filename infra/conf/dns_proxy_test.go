@@ -29,5 +29,46 @@ func TestDnsProxyConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			Input: `{
+				"fullServer": true,
+				"unsupportedQuery": "reject"
+			}`,
+			Parser: loadJSON(creator),
+			Output: &dns.Config{
+				Server:                 &net.Endpoint{},
+				FullServer:             true,
+				UnsupportedQueryAction: dns.UnsupportedQueryAction_REJECT,
+			},
+		},
+	})
+}
+
+func TestDnsInboundConfig(t *testing.T) {
+	creator := func() Buildable {
+		return new(DnsInboundConfig)
+	}
+
+	runMultiTestCase(t, []TestCase{
+		{
+			Input:  `{}`,
+			Parser: loadJSON(creator),
+			Output: &dns.ServerConfig{},
+		},
+		{
+			Input: `{
+				"fullServer": true,
+				"unsupportedQuery": "empty",
+				"dohPath": "/dns-query",
+				"userLevel": 1
+			}`,
+			Parser: loadJSON(creator),
+			Output: &dns.ServerConfig{
+				FullServer:             true,
+				UnsupportedQueryAction: dns.UnsupportedQueryAction_EMPTY,
+				DohPath:                "/dns-query",
+				UserLevel:              1,
+			},
+		},
 	})
 }
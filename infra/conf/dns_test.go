@@ -126,5 +126,194 @@ func TestDnsConfigParsing(t *testing.T) {
 				ClientIp: []byte{10, 0, 0, 1},
 			},
 		},
+		{
+			Input: `{
+				"servers": [{
+					"address": "1.1.1.1",
+					"disableCache": true
+				}, "8.8.8.8"],
+				"cache": {
+					"size": 1024,
+					"minTTL": 60,
+					"maxTTL": 3600,
+					"serveStale": true
+				}
+			}`,
+			Parser: parserCreator(),
+			Output: &dns.Config{
+				NameServer: []*dns.NameServer{
+					{
+						Address: &net.Endpoint{
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Ip{
+									Ip: []byte{1, 1, 1, 1},
+								},
+							},
+							Network: net.Network_UDP,
+						},
+						DisableCache: true,
+					},
+					{
+						Address: &net.Endpoint{
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Ip{
+									Ip: []byte{8, 8, 8, 8},
+								},
+							},
+							Network: net.Network_UDP,
+						},
+					},
+				},
+				Cache: &dns.CacheConfig{
+					Size:       1024,
+					MinTtl:     60,
+					MaxTtl:     3600,
+					ServeStale: true,
+				},
+			},
+		},
+		{
+			Input: `{
+				"servers": [{
+					"address": "1.1.1.1",
+					"queryStrategy": "UseIPv6"
+				}],
+				"queryStrategy": "PreferIPv4"
+			}`,
+			Parser: parserCreator(),
+			Output: &dns.Config{
+				NameServer: []*dns.NameServer{
+					{
+						Address: &net.Endpoint{
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Ip{
+									Ip: []byte{1, 1, 1, 1},
+								},
+							},
+							Network: net.Network_UDP,
+						},
+						QueryStrategy: dns.QueryStrategy_USE_IP6,
+					},
+				},
+				QueryStrategy: dns.QueryStrategy_PREFER_IP4,
+			},
+		},
+		{
+			Input: `{
+				"servers": [{
+					"address": "10.0.0.1",
+					"tag": "corp"
+				}],
+				"rules": [{
+					"domain": ["domain:ads.example.com"],
+					"action": "nxdomain"
+				}, {
+					"queryType": ["AAAA", "65"],
+					"action": "zeroIP"
+				}, {
+					"domain": ["domain:corp.example.com"],
+					"source": ["10.0.0.0/8"],
+					"inboundTag": ["dns-in"],
+					"server": "corp"
+				}]
+			}`,
+			Parser: parserCreator(),
+			Output: &dns.Config{
+				NameServer: []*dns.NameServer{
+					{
+						Address: &net.Endpoint{
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Ip{
+									Ip: []byte{10, 0, 0, 1},
+								},
+							},
+							Network: net.Network_UDP,
+						},
+						Tag: "corp",
+					},
+				},
+				Rule: []*dns.QueryRule{
+					{
+						Domain: []*router.Domain{{Type: router.Domain_Domain, Value: "ads.example.com"}},
+						Action: dns.QueryAction_NXDOMAIN,
+					},
+					{
+						QueryType: []uint32{28, 65},
+						Action:    dns.QueryAction_ZERO_IP,
+					},
+					{
+						Domain: []*router.Domain{{Type: router.Domain_Domain, Value: "corp.example.com"}},
+						SourceGeoip: []*router.GeoIP{
+							{
+								Cidr: []*router.CIDR{{Ip: []byte{10, 0, 0, 0}, Prefix: 8}},
+							},
+						},
+						InboundTag: []string{"dns-in"},
+						ServerTag:  "corp",
+					},
+				},
+			},
+		},
+		{
+			Input: `{
+				"hostsFiles": [{
+					"path": "/etc/hosts"
+				}, {
+					"path": "ads.txt",
+					"format": "domainList",
+					"address": "0.0.0.0"
+				}, {
+					"path": "cdn.txt",
+					"format": "domainList",
+					"address": "cdn.example.com"
+				}],
+				"hostsFileCheckInterval": 60
+			}`,
+			Parser: parserCreator(),
+			Output: &dns.Config{
+				HostsFile: []*dns.HostsFile{
+					{
+						Path: "/etc/hosts",
+					},
+					{
+						Path:   "ads.txt",
+						Format: dns.HostsFile_DOMAIN_LIST,
+						Ip:     [][]byte{{0, 0, 0, 0}},
+					},
+					{
+						Path:          "cdn.txt",
+						Format:        dns.HostsFile_DOMAIN_LIST,
+						ProxiedDomain: "cdn.example.com",
+					},
+				},
+				HostsFileCheckInterval: 60,
+			},
+		},
 	})
 }
+
+func TestDnsConfigInvalid(t *testing.T) {
+	for _, input := range []string{
+		`{"servers": ["quic://dns.adguard.com"]}`,
+		`{"servers": [{"address": "quic+local://dns.adguard.com", "port": 784}]}`,
+		`{"rules": [{"action": "drop"}]}`,
+		`{"rules": [{"action": "nxdomain", "server": "corp"}]}`,
+		`{"rules": [{"queryType": ["AXFR2"]}]}`,
+		`{"hostsFiles": [{"format": "hosts"}]}`,
+		`{"hostsFiles": [{"path": "ads.txt", "format": "domainList"}]}`,
+	} {
+		var c DnsConfig
+		common.Must(json.Unmarshal([]byte(input), &c))
+		if _, err := c.Build(); err == nil {
+			t.Error("expected an error for invalid config: ", input)
+		}
+	}
+}
+
+func TestDnsConfigInvalidQueryStrategy(t *testing.T) {
+	var c DnsConfig
+	common.Must(json.Unmarshal([]byte(`{"queryStrategy": "UseIPv5"}`), &c))
+	if _, err := c.Build(); err == nil {
+		t.Error("expected an error for unknown query strategy")
+	}
+}
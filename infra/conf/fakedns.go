@@ -0,0 +1,61 @@
+package conf
+
+import (
+	"encoding/json"
+
+	"github.com/golang/protobuf/proto"
+
+	"v2ray.com/core/app/dns/fakedns"
+)
+
+type FakeDNSPoolElementConfig struct {
+	IPPool  string `json:"ipPool"`
+	LRUSize int64  `json:"poolSize"`
+}
+
+// FakeDNSConfig is either a single pool, or a list of pools, such as one for IPv4 and one for IPv6.
+type FakeDNSConfig struct {
+	pool  *FakeDNSPoolElementConfig
+	pools []*FakeDNSPoolElementConfig
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.UnmarshalJSON
+func (f *FakeDNSConfig) UnmarshalJSON(data []byte) error {
+	var pool FakeDNSPoolElementConfig
+	var pools []*FakeDNSPoolElementConfig
+	switch {
+	case json.Unmarshal(data, &pool) == nil:
+		f.pool = &pool
+	case json.Unmarshal(data, &pools) == nil:
+		f.pools = pools
+	default:
+		return newError("invalid fakedns config")
+	}
+	return nil
+}
+
+func (f *FakeDNSConfig) Build() (proto.Message, error) {
+	if f.pool != nil {
+		if len(f.pool.IPPool) == 0 {
+			return nil, newError("fakedns ipPool can't be empty")
+		}
+		return &fakedns.FakeDnsPool{
+			IpPool:  f.pool.IPPool,
+			LruSize: f.pool.LRUSize,
+		}, nil
+	}
+	if len(f.pools) == 0 {
+		return nil, newError("no fakedns pool is specified")
+	}
+	config := new(fakedns.FakeDnsPoolMulti)
+	for _, pool := range f.pools {
+		if len(pool.IPPool) == 0 {
+			return nil, newError("fakedns ipPool can't be empty")
+		}
+		config.Pools = append(config.Pools, &fakedns.FakeDnsPool{
+			IpPool:  pool.IPPool,
+			LruSize: pool.LRUSize,
+		})
+	}
+	return config, nil
+}
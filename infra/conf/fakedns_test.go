@@ -0,0 +1,66 @@
+package conf_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"v2ray.com/core/app/dns/fakedns"
+	"v2ray.com/core/app/proxyman"
+	"v2ray.com/core/common"
+	"v2ray.com/core/infra/conf"
+)
+
+func TestFakeDNSConfig(t *testing.T) {
+	creator := func() conf.Buildable {
+		return new(conf.FakeDNSConfig)
+	}
+
+	runMultiTestCase(t, []TestCase{
+		{
+			Input: `{
+				"ipPool": "198.18.0.0/15",
+				"poolSize": 1024
+			}`,
+			Parser: loadJSON(creator),
+			Output: &fakedns.FakeDnsPool{
+				IpPool:  "198.18.0.0/15",
+				LruSize: 1024,
+			},
+		},
+		{
+			Input: `[{
+				"ipPool": "198.18.0.0/15"
+			}, {
+				"ipPool": "fc00::/18",
+				"poolSize": 1024
+			}]`,
+			Parser: loadJSON(creator),
+			Output: &fakedns.FakeDnsPoolMulti{
+				Pools: []*fakedns.FakeDnsPool{
+					{IpPool: "198.18.0.0/15"},
+					{IpPool: "fc00::/18", LruSize: 1024},
+				},
+			},
+		},
+	})
+}
+
+func TestSniffingConfigFakeDNS(t *testing.T) {
+	var c conf.SniffingConfig
+	common.Must(json.Unmarshal([]byte(`{
+		"enabled": true,
+		"destOverride": ["http", "fakedns"]
+	}`), &c))
+
+	config, err := c.Build()
+	common.Must(err)
+	expected := &proxyman.SniffingConfig{
+		Enabled:             true,
+		DestinationOverride: []string{"http", "fakedns"},
+	}
+	if !proto.Equal(config, expected) {
+		t.Error("expected ", expected, " but got ", config)
+	}
+}
@@ -0,0 +1,26 @@
+package conf
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"v2ray.com/core/app/observatory"
+)
+
+type ObservatoryConfig struct {
+	SubjectSelector []string `json:"subjectSelector"`
+	ProbeURL        string   `json:"probeURL"`
+	ProbeInterval   uint32   `json:"probeInterval"`
+	HistorySize     uint32   `json:"historySize"`
+}
+
+func (c *ObservatoryConfig) Build() (proto.Message, error) {
+	if len(c.SubjectSelector) == 0 {
+		return nil, newError("observatory subjectSelector can't be empty")
+	}
+	return &observatory.Config{
+		SubjectSelector: c.SubjectSelector,
+		ProbeUrl:        c.ProbeURL,
+		ProbeInterval:   c.ProbeInterval,
+		HistorySize:     c.HistorySize,
+	}, nil
+}
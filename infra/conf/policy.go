@@ -4,14 +4,35 @@ import (
 	"v2ray.com/core/app/policy"
 )
 
+// RateLimitConfig is the bandwidth limit in KB per second, with bursts in KB.
+type RateLimitConfig struct {
+	Uplink        uint64 `json:"uplink"`
+	Downlink      uint64 `json:"downlink"`
+	UplinkBurst   uint64 `json:"uplinkBurst"`
+	DownlinkBurst uint64 `json:"downlinkBurst"`
+}
+
+func (c *RateLimitConfig) Build() *policy.Policy_RateLimit {
+	return &policy.Policy_RateLimit{
+		Uplink:        c.Uplink * 1024,
+		Downlink:      c.Downlink * 1024,
+		UplinkBurst:   c.UplinkBurst * 1024,
+		DownlinkBurst: c.DownlinkBurst * 1024,
+	}
+}
+
 type Policy struct {
-	Handshake         *uint32 `json:"handshake"`
-	ConnectionIdle    *uint32 `json:"connIdle"`
-	UplinkOnly        *uint32 `json:"uplinkOnly"`
-	DownlinkOnly      *uint32 `json:"downlinkOnly"`
-	StatsUserUplink   bool    `json:"statsUserUplink"`
-	StatsUserDownlink bool    `json:"statsUserDownlink"`
-	BufferSize        *int32  `json:"bufferSize"`
+	Handshake         *uint32          `json:"handshake"`
+	ConnectionIdle    *uint32          `json:"connIdle"`
+	UplinkOnly        *uint32          `json:"uplinkOnly"`
+	DownlinkOnly      *uint32          `json:"downlinkOnly"`
+	StatsUserUplink   bool             `json:"statsUserUplink"`
+	StatsUserDownlink bool             `json:"statsUserDownlink"`
+	StatsUserOnline   bool             `json:"statsUserOnline"`
+	BufferSize        *int32           `json:"bufferSize"`
+	RateLimit         *RateLimitConfig `json:"rateLimit"`
+	MaxConnections    uint32           `json:"maxConnections"`
+	MaxSourceIPs      uint32           `json:"maxSourceIPs"`
 }
 
 func (t *Policy) Build() (*policy.Policy, error) {
@@ -34,6 +55,7 @@ func (t *Policy) Build() (*policy.Policy, error) {
 		Stats: &policy.Policy_Stats{
 			UserUplink:   t.StatsUserUplink,
 			UserDownlink: t.StatsUserDownlink,
+			UserOnline:   t.StatsUserOnline,
 		},
 	}
 
@@ -47,25 +69,47 @@ func (t *Policy) Build() (*policy.Policy, error) {
 		}
 	}
 
+	if t.RateLimit != nil {
+		p.RateLimit = t.RateLimit.Build()
+	}
+
+	if t.MaxConnections > 0 || t.MaxSourceIPs > 0 {
+		p.UserLimit = &policy.Policy_UserLimit{
+			MaxConnections: t.MaxConnections,
+			MaxSourceIps:   t.MaxSourceIPs,
+		}
+	}
+
 	return p, nil
 }
 
 type SystemPolicy struct {
-	StatsInboundUplink    bool `json:"statsInboundUplink"`
-	StatsInboundDownlink  bool `json:"statsInboundDownlink"`
-	StatsOutboundUplink   bool `json:"statsOutboundUplink"`
-	StatsOutboundDownlink bool `json:"statsOutboundDownlink"`
+	StatsInboundUplink    bool                        `json:"statsInboundUplink"`
+	StatsInboundDownlink  bool                        `json:"statsInboundDownlink"`
+	StatsOutboundUplink   bool                        `json:"statsOutboundUplink"`
+	StatsOutboundDownlink bool                        `json:"statsOutboundDownlink"`
+	InboundRateLimit      map[string]*RateLimitConfig `json:"inboundRateLimit"`
 }
 
 func (p *SystemPolicy) Build() (*policy.SystemPolicy, error) {
-	return &policy.SystemPolicy{
+	sp := &policy.SystemPolicy{
 		Stats: &policy.SystemPolicy_Stats{
 			InboundUplink:    p.StatsInboundUplink,
 			InboundDownlink:  p.StatsInboundDownlink,
 			OutboundUplink:   p.StatsOutboundUplink,
 			OutboundDownlink: p.StatsOutboundDownlink,
 		},
-	}, nil
+	}
+	for tag, rl := range p.InboundRateLimit {
+		if rl == nil {
+			continue
+		}
+		if sp.InboundRateLimit == nil {
+			sp.InboundRateLimit = make(map[string]*policy.Policy_RateLimit)
+		}
+		sp.InboundRateLimit[tag] = rl.Build()
+	}
+	return sp, nil
 }
 
 type PolicyConfig struct {
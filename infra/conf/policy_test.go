@@ -38,3 +38,41 @@ func TestBufferSize(t *testing.T) {
 		}
 	}
 }
+
+func TestRateLimit(t *testing.T) {
+	pConf := Policy{
+		RateLimit: &RateLimitConfig{
+			Uplink:        100,
+			Downlink:      200,
+			DownlinkBurst: 400,
+		},
+	}
+	p, err := pConf.Build()
+	common.Must(err)
+	if p.RateLimit.Uplink != 100*1024 || p.RateLimit.Downlink != 200*1024 || p.RateLimit.DownlinkBurst != 400*1024 {
+		t.Error("unexpected rate limit: ", p.RateLimit)
+	}
+
+	sConf := SystemPolicy{
+		InboundRateLimit: map[string]*RateLimitConfig{
+			"socks": {Uplink: 1024},
+		},
+	}
+	sp, err := sConf.Build()
+	common.Must(err)
+	if rl := sp.InboundRateLimit["socks"]; rl == nil || rl.Uplink != 1024*1024 {
+		t.Error("unexpected inbound rate limit: ", sp.InboundRateLimit)
+	}
+}
+
+func TestUserLimit(t *testing.T) {
+	pConf := Policy{
+		MaxConnections: 16,
+		MaxSourceIPs:   2,
+	}
+	p, err := pConf.Build()
+	common.Must(err)
+	if p.UserLimit.MaxConnections != 16 || p.UserLimit.MaxSourceIps != 2 {
+		t.Error("unexpected user limit: ", p.UserLimit)
+	}
+}
@@ -17,9 +17,17 @@ type RouterRulesConfig struct {
 	DomainStrategy string            `json:"domainStrategy"`
 }
 
+type BalancingStrategyConfig struct {
+	Type      string `json:"type"`
+	Tolerance uint32 `json:"tolerance"`
+	Baseline  uint32 `json:"baseline"`
+}
+
 type BalancingRule struct {
-	Tag       string     `json:"tag"`
-	Selectors StringList `json:"selector"`
+	Tag         string                   `json:"tag"`
+	Selectors   StringList               `json:"selector"`
+	Strategy    *BalancingStrategyConfig `json:"strategy"`
+	FallbackTag string                   `json:"fallbackTag"`
 }
 
 func (r *BalancingRule) Build() (*router.BalancingRule, error) {
@@ -30,10 +38,33 @@ func (r *BalancingRule) Build() (*router.BalancingRule, error) {
 		return nil, newError("empty selector list")
 	}
 
-	return &router.BalancingRule{
+	rule := &router.BalancingRule{
 		Tag:              r.Tag,
 		OutboundSelector: []string(r.Selectors),
-	}, nil
+		FallbackTag:      r.FallbackTag,
+	}
+	if r.Strategy == nil {
+		return rule, nil
+	}
+
+	switch strings.ToLower(r.Strategy.Type) {
+	case "", "random":
+		rule.Strategy = router.BalancingRule_Random
+	case "roundrobin":
+		rule.Strategy = router.BalancingRule_RoundRobin
+	case "leastping":
+		rule.Strategy = router.BalancingRule_LeastPing
+		rule.LeastPing = &router.LeastPingConfig{
+			Tolerance: r.Strategy.Tolerance,
+			Baseline:  r.Strategy.Baseline,
+		}
+	case "leastload":
+		rule.Strategy = router.BalancingRule_LeastLoad
+	default:
+		return nil, newError("unknown balancing strategy: ", r.Strategy.Type)
+	}
+
+	return rule, nil
 }
 
 type RouterConfig struct {
@@ -92,6 +123,7 @@ type RouterRule struct {
 	Type        string `json:"type"`
 	OutboundTag string `json:"outboundTag"`
 	BalancerTag string `json:"balancerTag"`
+	RuleTag     string `json:"ruleTag"`
 }
 
 func ParseIP(s string) (*router.CIDR, error) {
@@ -391,7 +423,9 @@ func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
 		return nil, err
 	}
 
-	rule := new(router.RoutingRule)
+	rule := &router.RoutingRule{
+		RuleTag: rawFieldRule.RuleTag,
+	}
 	if len(rawFieldRule.OutboundTag) > 0 {
 		rule.TargetTag = &router.RoutingRule_Tag{
 			Tag: rawFieldRule.OutboundTag,
@@ -35,7 +35,8 @@ func TestRouterConfig(t *testing.T) {
 								"baidu.com",
 								"qq.com"
 							],
-							"outboundTag": "direct"
+							"outboundTag": "direct",
+							"ruleTag": "domestic"
 						},
 						{
 							"type": "field",
@@ -59,6 +60,16 @@ func TestRouterConfig(t *testing.T) {
 					{
 						"tag": "b1",
 						"selector": ["test"]
+					},
+					{
+						"tag": "b2",
+						"selector": ["test"],
+						"fallbackTag": "direct",
+						"strategy": {
+							"type": "leastPing",
+							"tolerance": 50,
+							"baseline": 100
+						}
 					}
 				]
 			}`,
@@ -70,6 +81,16 @@ func TestRouterConfig(t *testing.T) {
 						Tag:              "b1",
 						OutboundSelector: []string{"test"},
 					},
+					{
+						Tag:              "b2",
+						OutboundSelector: []string{"test"},
+						Strategy:         router.BalancingRule_LeastPing,
+						FallbackTag:      "direct",
+						LeastPing: &router.LeastPingConfig{
+							Tolerance: 50,
+							Baseline:  100,
+						},
+					},
 				},
 				Rule: []*router.RoutingRule{
 					{
@@ -86,6 +107,7 @@ func TestRouterConfig(t *testing.T) {
 						TargetTag: &router.RoutingRule_Tag{
 							Tag: "direct",
 						},
+						RuleTag: "domestic",
 					},
 					{
 						Geoip: []*router.GeoIP{
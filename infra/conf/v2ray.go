@@ -15,6 +15,7 @@ import (
 
 var (
 	inboundConfigLoader = NewJSONConfigLoader(ConfigCreatorCache{
+		"dns":           func() interface{} { return new(DnsInboundConfig) },
 		"dokodemo-door": func() interface{} { return new(DokodemoConfig) },
 		"http":          func() interface{} { return new(HttpServerConfig) },
 		"shadowsocks":   func() interface{} { return new(ShadowsocksServerConfig) },
@@ -68,6 +69,8 @@ func (c *SniffingConfig) Build() (*proxyman.SniffingConfig, error) {
 				p = append(p, "http")
 			case "tls", "https", "ssl":
 				p = append(p, "tls")
+			case "fakedns":
+				p = append(p, "fakedns")
 			default:
 				return nil, newError("unknown protocol: ", domainOverride)
 			}
@@ -286,10 +289,65 @@ func (c *OutboundDetourConfig) Build() (*core.OutboundHandlerConfig, error) {
 	}, nil
 }
 
-type StatsConfig struct{}
+type StatsExporterConfig struct {
+	Listen string `json:"listen"`
+	Path   string `json:"path"`
+}
+
+type StatsChannelConfig struct {
+	Blocking        bool  `json:"blocking"`
+	SubscriberLimit int32 `json:"subscriberLimit"`
+	BufferSize      int32 `json:"bufferSize"`
+}
+
+type StatsPersistenceConfig struct {
+	Path     string     `json:"path"`
+	Interval uint32     `json:"interval"`
+	Patterns StringList `json:"patterns"`
+}
+
+type StatsConfig struct {
+	Exporter *StatsExporterConfig `json:"exporter"`
+	Channel  *StatsChannelConfig  `json:"channel"`
+	// OnlineTimeout is the number of seconds before an idle IP goes offline.
+	OnlineTimeout uint32                  `json:"onlineTimeout"`
+	Persistence   *StatsPersistenceConfig `json:"persistence"`
+}
 
 func (c *StatsConfig) Build() (*stats.Config, error) {
-	return &stats.Config{}, nil
+	config := &stats.Config{
+		OnlineTimeout: c.OnlineTimeout,
+	}
+	if c.Exporter != nil {
+		if len(c.Exporter.Listen) == 0 {
+			return nil, newError("stats exporter: listen address is not specified")
+		}
+		if len(c.Exporter.Path) > 0 && !strings.HasPrefix(c.Exporter.Path, "/") {
+			return nil, newError("stats exporter: path must start with /: ", c.Exporter.Path)
+		}
+		config.Exporter = &stats.ExporterConfig{
+			Listen: c.Exporter.Listen,
+			Path:   c.Exporter.Path,
+		}
+	}
+	if c.Channel != nil {
+		config.Channel = &stats.ChannelConfig{
+			Blocking:        c.Channel.Blocking,
+			SubscriberLimit: c.Channel.SubscriberLimit,
+			BufferSize:      c.Channel.BufferSize,
+		}
+	}
+	if c.Persistence != nil {
+		if len(c.Persistence.Path) == 0 {
+			return nil, newError("stats persistence: path is not specified")
+		}
+		config.Persistence = &stats.PersistenceConfig{
+			Path:     c.Persistence.Path,
+			Interval: c.Persistence.Interval,
+			Pattern:  []string(c.Persistence.Patterns),
+		}
+	}
+	return config, nil
 }
 
 type Config struct {
@@ -308,6 +366,8 @@ type Config struct {
 	Api             *ApiConfig             `json:"api"`
 	Stats           *StatsConfig           `json:"stats"`
 	Reverse         *ReverseConfig         `json:"reverse"`
+	Observatory     *ObservatoryConfig     `json:"observatory"`
+	FakeDNS         *FakeDNSConfig         `json:"fakedns"`
 }
 
 func (c *Config) findInboundTag(tag string) int {
@@ -361,6 +421,12 @@ func (c *Config) Override(o *Config, fn string) {
 	if o.Reverse != nil {
 		c.Reverse = o.Reverse
 	}
+	if o.Observatory != nil {
+		c.Observatory = o.Observatory
+	}
+	if o.FakeDNS != nil {
+		c.FakeDNS = o.FakeDNS
+	}
 
 	// deprecated attrs... keep them for now
 	if o.InboundConfig != nil {
@@ -499,6 +565,22 @@ func (c *Config) Build() (*core.Config, error) {
 		config.App = append(config.App, serial.ToTypedMessage(r))
 	}
 
+	if c.Observatory != nil {
+		o, err := c.Observatory.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.App = append(config.App, serial.ToTypedMessage(o))
+	}
+
+	if c.FakeDNS != nil {
+		f, err := c.FakeDNS.Build()
+		if err != nil {
+			return nil, newError("failed to parse fakedns config").Base(err)
+		}
+		config.App = append(config.App, serial.ToTypedMessage(f))
+	}
+
 	var inbounds []InboundDetourConfig
 
 	if c.InboundConfig != nil {
@@ -76,7 +76,12 @@ func TestVMessInbound(t *testing.T) {
 						"level": 0,
 						"alterId": 16,
 						"email": "love@v2ray.com",
-						"security": "aes-128-gcm"
+						"security": "aes-128-gcm",
+						"quota": {
+							"total": 1073741824,
+							"periodic": 104857600,
+							"period": 86400
+						}
 					}
 				],
 				"default": {
@@ -101,6 +106,11 @@ func TestVMessInbound(t *testing.T) {
 								Type: protocol.SecurityType_AES128_GCM,
 							},
 						}),
+						Quota: &protocol.Quota{
+							Total:    1073741824,
+							Periodic: 104857600,
+							Period:   86400,
+						},
 					},
 				},
 				Default: &inbound.DefaultConfig{
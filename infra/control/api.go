@@ -11,7 +11,10 @@ import (
 	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc"
 
+	dispatcherService "v2ray.com/core/app/dispatcher/command"
 	logService "v2ray.com/core/app/log/command"
+	observatoryService "v2ray.com/core/app/observatory/command"
+	routingService "v2ray.com/core/app/router/command"
 	statsService "v2ray.com/core/app/stats/command"
 	"v2ray.com/core/common"
 )
@@ -32,12 +35,39 @@ func (c *ApiCommand) Description() Description {
 			"\tLoggerService.RestartLogger",
 			"\tStatsService.GetStats",
 			"\tStatsService.QueryStats",
+			"\tStatsService.QueryMetrics",
+			"\tStatsService.GetUsersOnline",
+			"\tStatsService.GetUserIPs",
+			"\tObservatoryService.GetOutboundStatus",
+			"\tRoutingService.AddRule",
+			"\tRoutingService.RemoveRule",
+			"\tRoutingService.ListRules",
+			"\tRoutingService.ReplaceRules",
+			"\tRoutingService.TestRoute",
+			"\tDispatcherService.ListSessions",
+			"\tDispatcherService.CloseSessions",
+			"\tDispatcherService.SetUserQuota",
+			"\tDispatcherService.ResetUserQuota",
+			"\tDispatcherService.GetUserQuota",
 			"API calls in this command have a timeout to the server of 3 seconds.",
 			"Examples:",
 			"v2ctl api --server=127.0.0.1:8080 LoggerService.RestartLogger '' ",
 			"v2ctl api --server=127.0.0.1:8080 StatsService.QueryStats 'pattern: \"\" reset: false'",
+			"v2ctl api --server=127.0.0.1:8080 StatsService.QueryStats 'patterns: \"^user>>>\" patterns: \"^inbound>>>\" regexp: true reset: true'",
 			"v2ctl api --server=127.0.0.1:8080 StatsService.GetStats 'name: \"inbound>>>statin>>>traffic>>>downlink\" reset: false'",
 			"v2ctl api --server=127.0.0.1:8080 StatsService.GetSysStats ''",
+			"v2ctl api --server=127.0.0.1:8080 StatsService.QueryMetrics 'pattern: \"outbound>>>\"'",
+			"v2ctl api --server=127.0.0.1:8080 StatsService.GetUsersOnline ''",
+			"v2ctl api --server=127.0.0.1:8080 StatsService.GetUserIPs 'email: \"love@v2ray.com\"'",
+			"v2ctl api --server=127.0.0.1:8080 ObservatoryService.GetOutboundStatus 'tag_selector: \"proxy\"'",
+			"v2ctl api --server=127.0.0.1:8080 RoutingService.AddRule 'rule: <rule_tag: \"block-ads\" tag: \"blocked\" domain: <type: Domain value: \"ads.example.com\">> prepend: true'",
+			"v2ctl api --server=127.0.0.1:8080 RoutingService.RemoveRule 'rule_tag: \"block-ads\"'",
+			"v2ctl api --server=127.0.0.1:8080 RoutingService.ListRules ''",
+			"v2ctl api --server=127.0.0.1:8080 RoutingService.TestRoute 'target_address: <domain: \"www.v2ray.com\"> target_port: 443'",
+			"v2ctl api --server=127.0.0.1:8080 DispatcherService.ListSessions 'filter: <inbound_tag: \"vmess-in\">'",
+			"v2ctl api --server=127.0.0.1:8080 DispatcherService.CloseSessions 'filter: <user_email: \"love@v2ray.com\">'",
+			"v2ctl api --server=127.0.0.1:8080 DispatcherService.SetUserQuota 'user_email: \"love@v2ray.com\" quota: <total: 107374182400>'",
+			"v2ctl api --server=127.0.0.1:8080 DispatcherService.GetUserQuota 'user_email: \"love@v2ray.com\"'",
 		},
 	}
 }
@@ -93,8 +123,11 @@ func getServiceMethod(s string) (string, string) {
 type serviceHandler func(ctx context.Context, conn *grpc.ClientConn, method string, request string) (string, error)
 
 var serivceHandlerMap = map[string]serviceHandler{
-	"statsservice":  callStatsService,
-	"loggerservice": callLogService,
+	"statsservice":       callStatsService,
+	"loggerservice":      callLogService,
+	"observatoryservice": callObservatoryService,
+	"routingservice":     callRoutingService,
+	"dispatcherservice":  callDispatcherService,
 }
 
 func callLogService(ctx context.Context, conn *grpc.ClientConn, method string, request string) (string, error) {
@@ -148,6 +181,169 @@ func callStatsService(ctx context.Context, conn *grpc.ClientConn, method string,
 			return "", err
 		}
 		return proto.MarshalTextString(resp), nil
+	case "querymetrics":
+		r := &statsService.QueryMetricsRequest{}
+		if err := proto.UnmarshalText(request, r); err != nil {
+			return "", err
+		}
+		resp, err := client.QueryMetrics(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	case "getusersonline":
+		// GetUsersOnlineRequest is an empty message
+		r := &statsService.GetUsersOnlineRequest{}
+		resp, err := client.GetUsersOnline(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	case "getuserips":
+		r := &statsService.GetUserIPsRequest{}
+		if err := proto.UnmarshalText(request, r); err != nil {
+			return "", err
+		}
+		resp, err := client.GetUserIPs(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	default:
+		return "", errors.New("Unknown method: " + method)
+	}
+}
+
+func callObservatoryService(ctx context.Context, conn *grpc.ClientConn, method string, request string) (string, error) {
+	client := observatoryService.NewObservatoryServiceClient(conn)
+
+	switch strings.ToLower(method) {
+	case "getoutboundstatus":
+		r := &observatoryService.GetOutboundStatusRequest{}
+		if err := proto.UnmarshalText(request, r); err != nil {
+			return "", err
+		}
+		resp, err := client.GetOutboundStatus(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	default:
+		return "", errors.New("Unknown method: " + method)
+	}
+}
+
+func callRoutingService(ctx context.Context, conn *grpc.ClientConn, method string, request string) (string, error) {
+	client := routingService.NewRoutingServiceClient(conn)
+
+	switch strings.ToLower(method) {
+	case "addrule":
+		r := &routingService.AddRuleRequest{}
+		if err := proto.UnmarshalText(request, r); err != nil {
+			return "", err
+		}
+		resp, err := client.AddRule(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	case "removerule":
+		r := &routingService.RemoveRuleRequest{}
+		if err := proto.UnmarshalText(request, r); err != nil {
+			return "", err
+		}
+		resp, err := client.RemoveRule(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	case "listrules":
+		// ListRulesRequest is an empty message
+		r := &routingService.ListRulesRequest{}
+		resp, err := client.ListRules(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	case "replacerules":
+		r := &routingService.ReplaceRulesRequest{}
+		if err := proto.UnmarshalText(request, r); err != nil {
+			return "", err
+		}
+		resp, err := client.ReplaceRules(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	case "testroute":
+		r := &routingService.TestRouteRequest{}
+		if err := proto.UnmarshalText(request, r); err != nil {
+			return "", err
+		}
+		resp, err := client.TestRoute(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	default:
+		return "", errors.New("Unknown method: " + method)
+	}
+}
+
+func callDispatcherService(ctx context.Context, conn *grpc.ClientConn, method string, request string) (string, error) {
+	client := dispatcherService.NewDispatcherServiceClient(conn)
+
+	switch strings.ToLower(method) {
+	case "listsessions":
+		r := &dispatcherService.ListSessionsRequest{}
+		if err := proto.UnmarshalText(request, r); err != nil {
+			return "", err
+		}
+		resp, err := client.ListSessions(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	case "closesessions":
+		r := &dispatcherService.CloseSessionsRequest{}
+		if err := proto.UnmarshalText(request, r); err != nil {
+			return "", err
+		}
+		resp, err := client.CloseSessions(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	case "setuserquota":
+		r := &dispatcherService.SetUserQuotaRequest{}
+		if err := proto.UnmarshalText(request, r); err != nil {
+			return "", err
+		}
+		resp, err := client.SetUserQuota(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	case "resetuserquota":
+		r := &dispatcherService.ResetUserQuotaRequest{}
+		if err := proto.UnmarshalText(request, r); err != nil {
+			return "", err
+		}
+		resp, err := client.ResetUserQuota(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
+	case "getuserquota":
+		r := &dispatcherService.GetUserQuotaRequest{}
+		if err := proto.UnmarshalText(request, r); err != nil {
+			return "", err
+		}
+		resp, err := client.GetUserQuota(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return proto.MarshalTextString(resp), nil
 	default:
 		return "", errors.New("Unknown method: " + method)
 	}
@@ -0,0 +1,133 @@
+package control
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+
+	routingService "v2ray.com/core/app/router/command"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+)
+
+type RouteTestCommand struct{}
+
+func (c *RouteTestCommand) Name() string {
+	return "route-test"
+}
+
+func (c *RouteTestCommand) Description() Description {
+	return Description{
+		Short: "Explain how a connection would be routed",
+		Usage: []string{
+			"v2ctl route-test [--server=127.0.0.1:8080] [options] [tcp:|udp:]<target>:<port>",
+			"Ask a running V2Ray process which routing rule matches a hypothetical connection.",
+			"RoutingService must be enabled in the API settings of the process.",
+			"Examples:",
+			"v2ctl route-test www.v2ray.com:443",
+			"v2ctl route-test --inbound=socks-in --user=love@v2ray.com --protocol=tls www.v2ray.com:443",
+			"v2ctl route-test --source=192.168.1.2:50000 udp:8.8.8.8:53",
+		},
+	}
+}
+
+type attributeFlag map[string]string
+
+func (f attributeFlag) String() string {
+	return fmt.Sprint(map[string]string(f))
+}
+
+func (f attributeFlag) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 || len(kv[0]) == 0 {
+		return newError("invalid attribute: ", value, ", expecting key=value")
+	}
+	f[kv[0]] = kv[1]
+	return nil
+}
+
+func (c *RouteTestCommand) Execute(args []string) error {
+	fs := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+
+	serverAddr := fs.String("server", "127.0.0.1:8080", "Server address")
+	inboundTag := fs.String("inbound", "", "Tag of the inbound the connection comes from")
+	userEmail := fs.String("user", "", "Email of the user")
+	source := fs.String("source", "", "Source address of the connection, in the form of ip[:port]")
+	sniffedProtocol := fs.String("protocol", "", "Sniffed protocol, such as http, tls or bittorrent")
+	attributes := make(attributeFlag)
+	fs.Var(attributes, "attr", "Attribute of the connection in the form of key=value, may be specified multiple times")
+
+	if err := fs.Parse(args); err != nil {
+		return newError("flag parsing").Base(err)
+	}
+
+	if fs.NArg() < 1 {
+		return newError("target not specified")
+	}
+
+	target, err := net.ParseDestination(fs.Arg(0))
+	if err != nil {
+		return newError("invalid target: ", fs.Arg(0)).Base(err)
+	}
+
+	request := &routingService.TestRouteRequest{
+		InboundTag:    *inboundTag,
+		UserEmail:     *userEmail,
+		TargetAddress: net.NewIPOrDomain(target.Address),
+		TargetPort:    uint32(target.Port),
+		Network:       target.Network,
+		Protocol:      *sniffedProtocol,
+		Attributes:    attributes,
+	}
+	if len(*source) > 0 {
+		host, port := *source, "0"
+		if h, p, err := net.SplitHostPort(*source); err == nil {
+			host, port = h, p
+		}
+		sourcePort, err := net.PortFromString(port)
+		if err != nil {
+			return newError("invalid source: ", *source).Base(err)
+		}
+		request.SourceAddress = net.NewIPOrDomain(net.ParseAddress(host))
+		request.SourcePort = uint32(sourcePort)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, *serverAddr, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return newError("failed to dial ", *serverAddr).Base(err)
+	}
+	defer conn.Close()
+
+	resp, err := routingService.NewRoutingServiceClient(conn).TestRoute(ctx, request)
+	if err != nil {
+		return newError("failed to test route").Base(err)
+	}
+
+	if !resp.Matched {
+		fmt.Println("No rule matched. The connection goes to the default outbound.")
+	} else {
+		fmt.Printf("Matched rule: #%d %s\n", resp.RuleIndex, resp.RuleTag)
+		if len(resp.BalancerTag) > 0 {
+			fmt.Printf("Balancer: %s\n", resp.BalancerTag)
+		}
+		if len(resp.BalancerCandidates) > 0 {
+			fmt.Printf("Candidates: %s\n", strings.Join(resp.BalancerCandidates, ", "))
+		}
+		if len(resp.OutboundTag) > 0 {
+			fmt.Printf("Outbound: %s\n", resp.OutboundTag)
+		}
+	}
+	fmt.Printf("Domain resolved: %t\n", resp.DomainResolved)
+	return nil
+}
+
+func init() {
+	common.Must(RegisterCommand(&RouteTestCommand{}))
+}
@@ -10,13 +10,18 @@ import (
 
 	// Default commander and all its services. This is an optional feature.
 	_ "v2ray.com/core/app/commander"
+	_ "v2ray.com/core/app/dispatcher/command"
 	_ "v2ray.com/core/app/log/command"
+	_ "v2ray.com/core/app/observatory/command"
 	_ "v2ray.com/core/app/proxyman/command"
+	_ "v2ray.com/core/app/router/command"
 	_ "v2ray.com/core/app/stats/command"
 
 	// Other optional features.
 	_ "v2ray.com/core/app/dns"
+	_ "v2ray.com/core/app/dns/fakedns"
 	_ "v2ray.com/core/app/log"
+	_ "v2ray.com/core/app/observatory"
 	_ "v2ray.com/core/app/policy"
 	_ "v2ray.com/core/app/reverse"
 	_ "v2ray.com/core/app/router"
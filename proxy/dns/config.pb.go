@@ -20,6 +20,60 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+// UnsupportedQueryAction is the way to handle queries that are not answered by
+// the DNS app.
+type UnsupportedQueryAction int32
+
+const (
+	// Forward the query to the server as is.
+	UnsupportedQueryAction_FORWARD UnsupportedQueryAction = 0
+	// Respond with REFUSED.
+	UnsupportedQueryAction_REJECT UnsupportedQueryAction = 1
+	// Respond with no answer.
+	UnsupportedQueryAction_EMPTY UnsupportedQueryAction = 2
+)
+
+// Enum value maps for UnsupportedQueryAction.
+var (
+	UnsupportedQueryAction_name = map[int32]string{
+		0: "FORWARD",
+		1: "REJECT",
+		2: "EMPTY",
+	}
+	UnsupportedQueryAction_value = map[string]int32{
+		"FORWARD": 0,
+		"REJECT":  1,
+		"EMPTY":   2,
+	}
+)
+
+func (x UnsupportedQueryAction) Enum() *UnsupportedQueryAction {
+	p := new(UnsupportedQueryAction)
+	*p = x
+	return p
+}
+
+func (x UnsupportedQueryAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UnsupportedQueryAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_v2ray_com_core_proxy_dns_config_proto_enumTypes[0].Descriptor()
+}
+
+func (UnsupportedQueryAction) Type() protoreflect.EnumType {
+	return &file_v2ray_com_core_proxy_dns_config_proto_enumTypes[0]
+}
+
+func (x UnsupportedQueryAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UnsupportedQueryAction.Descriptor instead.
+func (UnsupportedQueryAction) EnumDescriptor() ([]byte, []int) {
+	return file_v2ray_com_core_proxy_dns_config_proto_rawDescGZIP(), []int{0}
+}
+
 type Config struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -27,6 +81,10 @@ type Config struct {
 
 	// Server is the DNS server address. If specified, this address overrides the original one.
 	Server *net.Endpoint `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	// Whether MX, TXT, SRV, CNAME and PTR queries are answered by the DNS app,
+	// as A and AAAA queries are.
+	FullServer             bool                   `protobuf:"varint,2,opt,name=full_server,json=fullServer,proto3" json:"full_server,omitempty"`
+	UnsupportedQueryAction UnsupportedQueryAction `protobuf:"varint,3,opt,name=unsupported_query_action,json=unsupportedQueryAction,proto3,enum=v2ray.core.proxy.dns.UnsupportedQueryAction" json:"unsupported_query_action,omitempty"`
 }
 
 func (x *Config) Reset() {
@@ -68,6 +126,100 @@ func (x *Config) GetServer() *net.Endpoint {
 	return nil
 }
 
+func (x *Config) GetFullServer() bool {
+	if x != nil {
+		return x.FullServer
+	}
+	return false
+}
+
+func (x *Config) GetUnsupportedQueryAction() UnsupportedQueryAction {
+	if x != nil {
+		return x.UnsupportedQueryAction
+	}
+	return UnsupportedQueryAction_FORWARD
+}
+
+// ServerConfig is the config of the DNS inbound, which answers queries over
+// UDP and TCP from the DNS app.
+type ServerConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether MX, TXT, SRV, CNAME and PTR queries are answered, as A and AAAA
+	// queries are.
+	FullServer bool `protobuf:"varint,1,opt,name=full_server,json=fullServer,proto3" json:"full_server,omitempty"`
+	// The way to handle queries of other types. As there is no server to forward
+	// to, FORWARD is the same as REJECT.
+	UnsupportedQueryAction UnsupportedQueryAction `protobuf:"varint,2,opt,name=unsupported_query_action,json=unsupportedQueryAction,proto3,enum=v2ray.core.proxy.dns.UnsupportedQueryAction" json:"unsupported_query_action,omitempty"`
+	// If not empty, TCP connections are served as DNS over HTTPS (RFC 8484) at
+	// this path, instead of DNS over TCP. TLS comes from the stream settings of
+	// the inbound.
+	DohPath   string `protobuf:"bytes,3,opt,name=doh_path,json=dohPath,proto3" json:"doh_path,omitempty"`
+	UserLevel uint32 `protobuf:"varint,4,opt,name=user_level,json=userLevel,proto3" json:"user_level,omitempty"`
+}
+
+func (x *ServerConfig) Reset() {
+	*x = ServerConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2ray_com_core_proxy_dns_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerConfig) ProtoMessage() {}
+
+func (x *ServerConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_v2ray_com_core_proxy_dns_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerConfig.ProtoReflect.Descriptor instead.
+func (*ServerConfig) Descriptor() ([]byte, []int) {
+	return file_v2ray_com_core_proxy_dns_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ServerConfig) GetFullServer() bool {
+	if x != nil {
+		return x.FullServer
+	}
+	return false
+}
+
+func (x *ServerConfig) GetUnsupportedQueryAction() UnsupportedQueryAction {
+	if x != nil {
+		return x.UnsupportedQueryAction
+	}
+	return UnsupportedQueryAction_FORWARD
+}
+
+func (x *ServerConfig) GetDohPath() string {
+	if x != nil {
+		return x.DohPath
+	}
+	return ""
+}
+
+func (x *ServerConfig) GetUserLevel() uint32 {
+	if x != nil {
+		return x.UserLevel
+	}
+	return 0
+}
+
 var File_v2ray_com_core_proxy_dns_config_proto protoreflect.FileDescriptor
 
 var file_v2ray_com_core_proxy_dns_config_proto_rawDesc = []byte{
@@ -77,15 +229,41 @@ var file_v2ray_com_core_proxy_dns_config_proto_rawDesc = []byte{
 	0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x64, 0x6e, 0x73, 0x1a, 0x2b, 0x76,
 	0x32, 0x72, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f,
 	0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x6e, 0x65, 0x74, 0x2f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x41, 0x0a, 0x06, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x76, 0x32, 0x72, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x72,
-	0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x45, 0x6e, 0x64,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x42, 0x38, 0x0a,
-	0x18, 0x63, 0x6f, 0x6d, 0x2e, 0x76, 0x32, 0x72, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x64, 0x6e, 0x73, 0x50, 0x01, 0x5a, 0x03, 0x64, 0x6e, 0x73,
-	0xaa, 0x02, 0x14, 0x56, 0x32, 0x52, 0x61, 0x79, 0x2e, 0x43, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72,
-	0x6f, 0x78, 0x79, 0x2e, 0x44, 0x6e, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xca, 0x01, 0x0a, 0x06, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x76, 0x32, 0x72, 0x61, 0x79, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x1f,
+	0x0a, 0x0b, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x66, 0x75, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x66, 0x0a, 0x18, 0x75, 0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x2c, 0x2e, 0x76, 0x32, 0x72, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x78, 0x79, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x55, 0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f,
+	0x72, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x16, 0x75, 0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xd1, 0x01, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x75, 0x6c, 0x6c,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x66,
+	0x75, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x66, 0x0a, 0x18, 0x75, 0x6e, 0x73,
+	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x76, 0x32,
+	0x72, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x64,
+	0x6e, 0x73, 0x2e, 0x55, 0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x16, 0x75, 0x6e, 0x73, 0x75, 0x70,
+	0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x6f, 0x68, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x6f, 0x68, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x2a, 0x3c, 0x0a, 0x16, 0x55,
+	0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44,
+	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x10, 0x01, 0x12, 0x09,
+	0x0a, 0x05, 0x45, 0x4d, 0x50, 0x54, 0x59, 0x10, 0x02, 0x42, 0x38, 0x0a, 0x18, 0x63, 0x6f, 0x6d,
+	0x2e, 0x76, 0x32, 0x72, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x2e, 0x64, 0x6e, 0x73, 0x50, 0x01, 0x5a, 0x03, 0x64, 0x6e, 0x73, 0xaa, 0x02, 0x14, 0x56,
+	0x32, 0x52, 0x61, 0x79, 0x2e, 0x43, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x2e,
+	0x44, 0x6e, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -100,18 +278,23 @@ func file_v2ray_com_core_proxy_dns_config_proto_rawDescGZIP() []byte {
 	return file_v2ray_com_core_proxy_dns_config_proto_rawDescData
 }
 
-var file_v2ray_com_core_proxy_dns_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_v2ray_com_core_proxy_dns_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_v2ray_com_core_proxy_dns_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_v2ray_com_core_proxy_dns_config_proto_goTypes = []interface{}{
-	(*Config)(nil),       // 0: v2ray.core.proxy.dns.Config
-	(*net.Endpoint)(nil), // 1: v2ray.core.common.net.Endpoint
+	(UnsupportedQueryAction)(0), // 0: v2ray.core.proxy.dns.UnsupportedQueryAction
+	(*Config)(nil),              // 1: v2ray.core.proxy.dns.Config
+	(*ServerConfig)(nil),        // 2: v2ray.core.proxy.dns.ServerConfig
+	(*net.Endpoint)(nil),        // 3: v2ray.core.common.net.Endpoint
 }
 var file_v2ray_com_core_proxy_dns_config_proto_depIdxs = []int32{
-	1, // 0: v2ray.core.proxy.dns.Config.server:type_name -> v2ray.core.common.net.Endpoint
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3, // 0: v2ray.core.proxy.dns.Config.server:type_name -> v2ray.core.common.net.Endpoint
+	0, // 1: v2ray.core.proxy.dns.Config.unsupported_query_action:type_name -> v2ray.core.proxy.dns.UnsupportedQueryAction
+	0, // 2: v2ray.core.proxy.dns.ServerConfig.unsupported_query_action:type_name -> v2ray.core.proxy.dns.UnsupportedQueryAction
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_v2ray_com_core_proxy_dns_config_proto_init() }
@@ -132,19 +315,32 @@ func file_v2ray_com_core_proxy_dns_config_proto_init() {
 				return nil
 			}
 		}
+		file_v2ray_com_core_proxy_dns_config_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v2ray_com_core_proxy_dns_config_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_v2ray_com_core_proxy_dns_config_proto_goTypes,
 		DependencyIndexes: file_v2ray_com_core_proxy_dns_config_proto_depIdxs,
+		EnumInfos:         file_v2ray_com_core_proxy_dns_config_proto_enumTypes,
 		MessageInfos:      file_v2ray_com_core_proxy_dns_config_proto_msgTypes,
 	}.Build()
 	File_v2ray_com_core_proxy_dns_config_proto = out.File
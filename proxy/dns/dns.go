@@ -7,8 +7,6 @@ import (
 	"io"
 	"sync"
 
-	"golang.org/x/net/dns/dnsmessage"
-
 	"v2ray.com/core"
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/buf"
@@ -38,24 +36,15 @@ type ownLinkVerifier interface {
 }
 
 type Handler struct {
-	ipv4Lookup      dns.IPv4Lookup
-	ipv6Lookup      dns.IPv6Lookup
+	responder
 	ownLinkVerifier ownLinkVerifier
 	server          net.Destination
 }
 
 func (h *Handler) Init(config *Config, dnsClient dns.Client) error {
-	ipv4lookup, ok := dnsClient.(dns.IPv4Lookup)
-	if !ok {
-		return newError("dns.Client doesn't implement IPv4Lookup")
-	}
-	h.ipv4Lookup = ipv4lookup
-
-	ipv6lookup, ok := dnsClient.(dns.IPv6Lookup)
-	if !ok {
-		return newError("dns.Client doesn't implement IPv6Lookup")
+	if err := h.responder.init(dnsClient, config.FullServer, config.UnsupportedQueryAction); err != nil {
+		return err
 	}
-	h.ipv6Lookup = ipv6lookup
 
 	if v, ok := dnsClient.(ownLinkVerifier); ok {
 		h.ownLinkVerifier = v
@@ -71,30 +60,6 @@ func (h *Handler) isOwnLink(ctx context.Context) bool {
 	return h.ownLinkVerifier != nil && h.ownLinkVerifier.IsOwnLink(ctx)
 }
 
-func parseIPQuery(b []byte) (r bool, domain string, id uint16, qType dnsmessage.Type) {
-	var parser dnsmessage.Parser
-	header, err := parser.Start(b)
-	if err != nil {
-		newError("parser start").Base(err).WriteToLog()
-		return
-	}
-
-	id = header.ID
-	q, err := parser.Question()
-	if err != nil {
-		newError("question").Base(err).WriteToLog()
-		return
-	}
-	qType = q.Type
-	if qType != dnsmessage.TypeA && qType != dnsmessage.TypeAAAA {
-		return
-	}
-
-	domain = q.Name.String()
-	r = true
-	return
-}
-
 // Process implements proxy.Outbound.
 func (h *Handler) Process(ctx context.Context, link *transport.Link, d internet.Dialer) error {
 	outbound := session.OutboundFromContext(ctx)
@@ -170,9 +135,10 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, d internet.
 			}
 
 			if !h.isOwnLink(ctx) {
-				isIPQuery, domain, id, qType := parseIPQuery(b.Bytes())
-				if isIPQuery {
-					go h.handleIPQuery(id, qType, domain, writer)
+				isQuery, header, q := parseQuery(b.Bytes())
+				if isQuery && (h.isAnswered(q.Type) || h.unsupported != UnsupportedQueryAction_FORWARD) {
+					b.Release()
+					go h.handleQuery(ctx, header, q, writer)
 					continue
 				}
 			}
@@ -207,67 +173,6 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, d internet.
 	return nil
 }
 
-func (h *Handler) handleIPQuery(id uint16, qType dnsmessage.Type, domain string, writer dns_proto.MessageWriter) {
-	var ips []net.IP
-	var err error
-
-	switch qType {
-	case dnsmessage.TypeA:
-		ips, err = h.ipv4Lookup.LookupIPv4(domain)
-	case dnsmessage.TypeAAAA:
-		ips, err = h.ipv6Lookup.LookupIPv6(domain)
-	}
-
-	rcode := dns.RCodeFromError(err)
-	if rcode == 0 && len(ips) == 0 && err != dns.ErrEmptyResponse {
-		newError("ip query").Base(err).WriteToLog()
-		return
-	}
-
-	b := buf.New()
-	rawBytes := b.Extend(buf.Size)
-	builder := dnsmessage.NewBuilder(rawBytes[:0], dnsmessage.Header{
-		ID:                 id,
-		RCode:              dnsmessage.RCode(rcode),
-		RecursionAvailable: true,
-		RecursionDesired:   true,
-		Response:           true,
-		Authoritative:      true,
-	})
-	builder.EnableCompression()
-	common.Must(builder.StartQuestions())
-	common.Must(builder.Question(dnsmessage.Question{
-		Name:  dnsmessage.MustNewName(domain),
-		Class: dnsmessage.ClassINET,
-		Type:  qType,
-	}))
-	common.Must(builder.StartAnswers())
-
-	rHeader := dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(domain), Class: dnsmessage.ClassINET, TTL: 600}
-	for _, ip := range ips {
-		if len(ip) == net.IPv4len {
-			var r dnsmessage.AResource
-			copy(r.A[:], ip)
-			common.Must(builder.AResource(rHeader, r))
-		} else {
-			var r dnsmessage.AAAAResource
-			copy(r.AAAA[:], ip)
-			common.Must(builder.AAAAResource(rHeader, r))
-		}
-	}
-	msgBytes, err := builder.Finish()
-	if err != nil {
-		newError("pack message").Base(err).WriteToLog()
-		b.Release()
-		return
-	}
-	b.Resize(0, int32(len(msgBytes)))
-
-	if err := writer.WriteMessage(b); err != nil {
-		newError("write IP answer").Base(err).WriteToLog()
-	}
-}
-
 type outboundConn struct {
 	access sync.Mutex
 	dialer func() (internet.Connection, error)
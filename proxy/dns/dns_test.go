@@ -15,11 +15,14 @@ import (
 	"v2ray.com/core/app/proxyman"
 	_ "v2ray.com/core/app/proxyman/inbound"
 	_ "v2ray.com/core/app/proxyman/outbound"
+	"v2ray.com/core/app/router"
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol"
 	"v2ray.com/core/common/serial"
 	dns_proxy "v2ray.com/core/proxy/dns"
 	"v2ray.com/core/proxy/dokodemo"
+	"v2ray.com/core/proxy/freedom"
 	"v2ray.com/core/testing/servers/tcp"
 	"v2ray.com/core/testing/servers/udp"
 )
@@ -52,6 +55,12 @@ func (*staticHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 				rr, _ := dns.NewRR("google.com. IN A 8.8.4.4")
 				ans.Answer = append(ans.Answer, rr)
 			}
+		} else if q.Name == "google.com." && q.Qtype == dns.TypeMX {
+			rr, _ := dns.NewRR("google.com. 300 IN MX 10 smtp.google.com.")
+			ans.Answer = append(ans.Answer, rr)
+		} else if q.Name == "google.com." && q.Qtype == dns.TypeTXT {
+			rr, _ := dns.NewRR("google.com. 300 IN TXT \"v=spf1 -all\"")
+			ans.Answer = append(ans.Answer, rr)
 		} else if q.Name == "facebook.com." && q.Qtype == dns.TypeA {
 			rr, _ := dns.NewRR("facebook.com. IN A 9.9.9.9")
 			ans.Answer = append(ans.Answer, rr)
@@ -188,6 +197,114 @@ func TestUDPDNSTunnel(t *testing.T) {
 	}
 }
 
+func TestDNSTunnelRouting(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+	defer dnsServer.Shutdown()
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	// Nothing listens on the name server of the DNS app. Its queries reach the DNS server only through the proxy
+	// outbound, to which they are routed by the tag of the DNS app.
+	deadPort := udp.PickPort()
+	serverPort := udp.PickPort()
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&dnsapp.Config{
+				NameServers: []*net.Endpoint{
+					{
+						Network: net.Network_UDP,
+						Address: net.NewIPOrDomain(net.LocalHostIP),
+						Port:    uint32(deadPort),
+					},
+				},
+				Tag: "dns",
+			}),
+			serial.ToTypedMessage(&router.Config{
+				Rule: []*router.RoutingRule{
+					{
+						InboundTag: []string{"dns-in"},
+						TargetTag:  &router.RoutingRule_Tag{Tag: "dns-out"},
+					},
+					{
+						InboundTag: []string{"dns"},
+						TargetTag:  &router.RoutingRule_Tag{Tag: "proxy"},
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Inbound: []*core.InboundHandlerConfig{
+			{
+				Tag: "dns-in",
+				ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+					Address:  net.NewIPOrDomain(net.LocalHostIP),
+					Port:     uint32(deadPort),
+					Networks: []net.Network{net.Network_UDP},
+				}),
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: net.SinglePortRange(serverPort),
+					Listen:    net.NewIPOrDomain(net.LocalHostIP),
+				}),
+			},
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				Tag:           "dns-out",
+				ProxySettings: serial.ToTypedMessage(&dns_proxy.Config{}),
+			},
+			{
+				Tag: "proxy",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{
+					DestinationOverride: &freedom.DestinationOverride{
+						Server: &protocol.ServerEndpoint{
+							Address: net.NewIPOrDomain(net.LocalHostIP),
+							Port:    uint32(port),
+						},
+					},
+				}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+	common.Must(v.Start())
+	defer v.Close()
+
+	m1 := new(dns.Msg)
+	m1.Id = dns.Id()
+	m1.RecursionDesired = true
+	m1.Question = make([]dns.Question, 1)
+	m1.Question[0] = dns.Question{Name: "google.com.", Qtype: dns.TypeA, Qclass: dns.ClassINET}
+
+	c := new(dns.Client)
+	c.Timeout = 10 * time.Second
+	in, _, err := c.Exchange(m1, "127.0.0.1:"+strconv.Itoa(int(serverPort)))
+	common.Must(err)
+
+	if len(in.Answer) != 1 {
+		t.Fatal("len(answer): ", len(in.Answer))
+	}
+	rr, ok := in.Answer[0].(*dns.A)
+	if !ok {
+		t.Fatal("not A record")
+	}
+	if r := cmp.Diff(rr.A[:], net.IP{8, 8, 8, 8}); r != "" {
+		t.Error(r)
+	}
+}
+
 func TestTCPDNSTunnel(t *testing.T) {
 	port := udp.PickPort()
 
@@ -363,3 +480,167 @@ func TestUDP2TCPDNSTunnel(t *testing.T) {
 		t.Error(r)
 	}
 }
+
+func TestDNSFullServer(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+	defer dnsServer.Shutdown()
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	serverPort := udp.PickPort()
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&dnsapp.Config{
+				NameServer: []*dnsapp.NameServer{
+					{
+						Address: &net.Endpoint{
+							Network: net.Network_UDP,
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Ip{
+									Ip: []byte{127, 0, 0, 1},
+								},
+							},
+							Port: uint32(port),
+						},
+					},
+				},
+				StaticHosts: []*dnsapp.Config_HostMapping{
+					{
+						Type:          dnsapp.DomainMatchingType_Full,
+						Domain:        "alias.v2ray.com",
+						ProxiedDomain: "google.com",
+					},
+					{
+						Type:   dnsapp.DomainMatchingType_Full,
+						Domain: "local.v2ray.com",
+						Ip:     [][]byte{{10, 0, 0, 1}},
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Inbound: []*core.InboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+					Address:  net.NewIPOrDomain(net.LocalHostIP),
+					Port:     uint32(port),
+					Networks: []net.Network{net.Network_UDP},
+				}),
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: net.SinglePortRange(serverPort),
+					Listen:    net.NewIPOrDomain(net.LocalHostIP),
+				}),
+			},
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&dns_proxy.Config{
+					FullServer:             true,
+					UnsupportedQueryAction: dns_proxy.UnsupportedQueryAction_REJECT,
+				}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+	common.Must(v.Start())
+	defer v.Close()
+
+	exchange := func(name string, qType uint16) *dns.Msg {
+		m1 := new(dns.Msg)
+		m1.Id = dns.Id()
+		m1.RecursionDesired = true
+		m1.Question = []dns.Question{{Name: name, Qtype: qType, Qclass: dns.ClassINET}}
+
+		c := new(dns.Client)
+		in, _, err := c.Exchange(m1, "127.0.0.1:"+serverPort.String())
+		common.Must(err)
+		return in
+	}
+
+	{
+		in := exchange("google.com.", dns.TypeMX)
+		if len(in.Answer) != 1 {
+			t.Fatal("len(answer): ", len(in.Answer))
+		}
+		rr, ok := in.Answer[0].(*dns.MX)
+		if !ok {
+			t.Fatal("not MX record")
+		}
+		if rr.Preference != 10 || rr.Mx != "smtp.google.com." || rr.Hdr.Ttl != 300 {
+			t.Error("unexpected MX record: ", rr)
+		}
+	}
+
+	{
+		in := exchange("google.com.", dns.TypeTXT)
+		if len(in.Answer) != 1 {
+			t.Fatal("len(answer): ", len(in.Answer))
+		}
+		rr, ok := in.Answer[0].(*dns.TXT)
+		if !ok {
+			t.Fatal("not TXT record")
+		}
+		if r := cmp.Diff(rr.Txt, []string{"v=spf1 -all"}); r != "" {
+			t.Error(r)
+		}
+	}
+
+	{
+		in := exchange("google.com.", dns.TypeA)
+		if len(in.Answer) != 1 {
+			t.Fatal("len(answer): ", len(in.Answer))
+		}
+		// TTL of the record from the server is 3600 seconds by default.
+		if ttl := in.Answer[0].Header().Ttl; ttl > 3600 || ttl < 3500 {
+			t.Error("unexpected TTL: ", ttl)
+		}
+	}
+
+	{
+		in := exchange("alias.v2ray.com.", dns.TypeCNAME)
+		if len(in.Answer) != 1 {
+			t.Fatal("len(answer): ", len(in.Answer))
+		}
+		rr, ok := in.Answer[0].(*dns.CNAME)
+		if !ok {
+			t.Fatal("not CNAME record")
+		}
+		if rr.Target != "google.com." {
+			t.Error("unexpected CNAME target: ", rr.Target)
+		}
+	}
+
+	{
+		in := exchange("1.0.0.10.in-addr.arpa.", dns.TypePTR)
+		if len(in.Answer) != 1 {
+			t.Fatal("len(answer): ", len(in.Answer))
+		}
+		rr, ok := in.Answer[0].(*dns.PTR)
+		if !ok {
+			t.Fatal("not PTR record")
+		}
+		if rr.Ptr != "local.v2ray.com." {
+			t.Error("unexpected PTR: ", rr.Ptr)
+		}
+	}
+
+	{
+		in := exchange("google.com.", dns.TypeNS)
+		if in.Rcode != dns.RcodeRefused {
+			t.Error("expected Refused, but got ", in.Rcode)
+		}
+	}
+}
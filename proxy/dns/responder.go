@@ -0,0 +1,176 @@
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/net"
+	dns_proto "v2ray.com/core/common/protocol/dns"
+	"v2ray.com/core/features/dns"
+)
+
+// responder answers DNS queries from a dns.Client. It is shared by the outbound and the inbound.
+type responder struct {
+	ipv4Lookup   dns.IPv4Lookup
+	ipv6Lookup   dns.IPv6Lookup
+	recordLookup dns.RecordLookup
+	fullServer   bool
+	unsupported  UnsupportedQueryAction
+}
+
+func (r *responder) init(dnsClient dns.Client, fullServer bool, unsupported UnsupportedQueryAction) error {
+	ipv4lookup, ok := dnsClient.(dns.IPv4Lookup)
+	if !ok {
+		return newError("dns.Client doesn't implement IPv4Lookup")
+	}
+	r.ipv4Lookup = ipv4lookup
+
+	ipv6lookup, ok := dnsClient.(dns.IPv6Lookup)
+	if !ok {
+		return newError("dns.Client doesn't implement IPv6Lookup")
+	}
+	r.ipv6Lookup = ipv6lookup
+
+	if v, ok := dnsClient.(dns.RecordLookup); ok {
+		r.recordLookup = v
+	}
+	if fullServer && r.recordLookup == nil {
+		return newError("dns.Client doesn't implement RecordLookup, which is required in full server mode")
+	}
+	r.fullServer = fullServer
+	r.unsupported = unsupported
+	return nil
+}
+
+func parseQuery(b []byte) (r bool, header dnsmessage.Header, q dnsmessage.Question) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(b)
+	if err != nil {
+		newError("parser start").Base(err).WriteToLog()
+		return
+	}
+
+	q, err = parser.Question()
+	if err != nil {
+		newError("question").Base(err).WriteToLog()
+		return
+	}
+
+	r = true
+	return
+}
+
+// isAnswered returns whether queries of the type are answered by the DNS app.
+func (r *responder) isAnswered(qType dnsmessage.Type) bool {
+	switch qType {
+	case dnsmessage.TypeA, dnsmessage.TypeAAAA:
+		return true
+	case dnsmessage.TypeMX, dnsmessage.TypeTXT, dnsmessage.TypeSRV, dnsmessage.TypeCNAME, dnsmessage.TypePTR:
+		return r.fullServer
+	default:
+		return false
+	}
+}
+
+func (r *responder) lookup(ctx context.Context, q dnsmessage.Question) ([]dnsmessage.Resource, error) {
+	domain := q.Name.String()
+	if r.recordLookup != nil {
+		return r.recordLookup.LookupRecords(ctx, domain, q.Type)
+	}
+
+	var ips []net.IP
+	var err error
+	switch q.Type {
+	case dnsmessage.TypeA:
+		ips, err = r.ipv4Lookup.LookupIPv4(domain)
+	case dnsmessage.TypeAAAA:
+		ips, err = r.ipv6Lookup.LookupIPv6(domain)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The TTL is unknown to dns.Client.
+	rHeader := dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: 600}
+	answers := make([]dnsmessage.Resource, 0, len(ips))
+	for _, ip := range ips {
+		if len(ip) == net.IPv4len {
+			var r dnsmessage.AResource
+			copy(r.A[:], ip)
+			answers = append(answers, dnsmessage.Resource{Header: rHeader, Body: &r})
+		} else {
+			var r dnsmessage.AAAAResource
+			copy(r.AAAA[:], ip)
+			answers = append(answers, dnsmessage.Resource{Header: rHeader, Body: &r})
+		}
+	}
+	return answers, nil
+}
+
+// respond returns the packed response to the query. Queries of unsupported types are rejected, unless the
+// action is EMPTY. An error is returned if the lookup fails without a response code.
+func (r *responder) respond(ctx context.Context, header dnsmessage.Header, q dnsmessage.Question) (*buf.Buffer, error) {
+	if !r.isAnswered(q.Type) {
+		rcode := dnsmessage.RCodeRefused
+		if r.unsupported == UnsupportedQueryAction_EMPTY {
+			rcode = dnsmessage.RCodeSuccess
+		}
+		return packResponse(header, q, rcode, nil)
+	}
+
+	answers, err := r.lookup(ctx, q)
+
+	rcode := dns.RCodeFromError(err)
+	if rcode == 0 && len(answers) == 0 && err != dns.ErrEmptyResponse {
+		return nil, newError(q.Type, " query").Base(err)
+	}
+
+	return packResponse(header, q, dnsmessage.RCode(rcode), answers)
+}
+
+func (r *responder) handleQuery(ctx context.Context, header dnsmessage.Header, q dnsmessage.Question, writer dns_proto.MessageWriter) {
+	b, err := r.respond(ctx, header, q)
+	if err != nil {
+		newError("failed to answer query").Base(err).WriteToLog()
+		return
+	}
+
+	if err := writer.WriteMessage(b); err != nil {
+		newError("write ", q.Type, " answer").Base(err).WriteToLog()
+	}
+}
+
+func packResponse(header dnsmessage.Header, q dnsmessage.Question, rcode dnsmessage.RCode, answers []dnsmessage.Resource) (*buf.Buffer, error) {
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:                 header.ID,
+			RCode:              rcode,
+			RecursionAvailable: true,
+			RecursionDesired:   header.RecursionDesired,
+			Response:           true,
+			Authoritative:      true,
+		},
+		Questions: []dnsmessage.Question{q},
+		Answers:   answers,
+	}
+
+	b := buf.New()
+	rawBytes := b.Extend(buf.Size)
+	msgBytes, err := msg.AppendPack(rawBytes[:0])
+	if err == nil && len(msgBytes) > len(rawBytes) {
+		// Answers don't fit in a buffer. The client is expected to retry over a different transport.
+		msg.Header.Truncated = true
+		msg.Answers = nil
+		msgBytes, err = msg.AppendPack(rawBytes[:0])
+	}
+	if err != nil {
+		b.Release()
+		return nil, newError("pack message").Base(err)
+	}
+	b.Resize(0, int32(len(msgBytes)))
+	return b, nil
+}
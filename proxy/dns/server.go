@@ -0,0 +1,298 @@
+// +build !confonly
+
+package dns
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"v2ray.com/core"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol"
+	dns_proto "v2ray.com/core/common/protocol/dns"
+	"v2ray.com/core/common/session"
+	"v2ray.com/core/common/signal"
+	"v2ray.com/core/common/task"
+	"v2ray.com/core/features/dns"
+	"v2ray.com/core/features/policy"
+	"v2ray.com/core/features/routing"
+	"v2ray.com/core/transport/internet"
+)
+
+func init() {
+	common.Must(common.RegisterConfig((*ServerConfig)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		s := new(Server)
+		if err := core.RequireFeatures(ctx, func(dnsClient dns.Client, pm policy.Manager) error {
+			return s.Init(config.(*ServerConfig), dnsClient, pm)
+		}); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}))
+}
+
+// Server is an inbound handler that answers DNS queries from the DNS app. Queries are looked up with the context of
+// the connection, so that the DNS app knows the inbound and the source of the client.
+type Server struct {
+	responder
+	config        *ServerConfig
+	policyManager policy.Manager
+}
+
+// Init initializes the Server with necessary parameters.
+func (s *Server) Init(config *ServerConfig, dnsClient dns.Client, pm policy.Manager) error {
+	if err := s.responder.init(dnsClient, config.FullServer, config.UnsupportedQueryAction); err != nil {
+		return err
+	}
+	if len(config.DohPath) > 0 && !strings.HasPrefix(config.DohPath, "/") {
+		return newError("DoH path must start with '/': ", config.DohPath)
+	}
+	s.config = config
+	s.policyManager = pm
+	return nil
+}
+
+// Network implements proxy.Inbound.
+func (*Server) Network() []net.Network {
+	return []net.Network{net.Network_TCP, net.Network_UDP}
+}
+
+// Process implements proxy.Inbound.
+func (s *Server) Process(ctx context.Context, network net.Network, conn internet.Connection, dispatcher routing.Dispatcher) error {
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		inbound.User = &protocol.MemoryUser{
+			Level: s.config.UserLevel,
+		}
+	}
+	newError("serving DNS queries from ", conn.RemoteAddr()).AtDebug().WriteToLog(session.ExportIDToError(ctx))
+
+	// Upstream queries of clients are routed by the tag of this inbound.
+	ctx = dns.ContextWithInboundRouting(ctx)
+
+	plcy := s.policyManager.ForLevel(s.config.UserLevel)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	timer := signal.CancelAfterInactivity(ctx, cancel, plcy.Timeouts.ConnectionIdle)
+
+	if network == net.Network_TCP && len(s.config.DohPath) > 0 {
+		return s.serveDoH(ctx, conn, timer)
+	}
+
+	var reader dns_proto.MessageReader
+	var writer dns_proto.MessageWriter
+	if network == net.Network_TCP {
+		reader = dns_proto.NewTCPReader(buf.NewReader(conn))
+		writer = &dns_proto.TCPWriter{
+			Writer: buf.NewWriter(conn),
+		}
+	} else {
+		reader = &dns_proto.UDPReader{
+			Reader: buf.NewPacketReader(conn),
+		}
+		writer = &dns_proto.UDPWriter{
+			Writer: &buf.SequentialWriter{Writer: conn},
+		}
+	}
+	writer = &lockedWriter{writer: writer}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	request := func() error {
+		for {
+			b, err := reader.ReadMessage()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			timer.Update()
+
+			isQuery, header, q := parseQuery(b.Bytes())
+			b.Release()
+			if !isQuery {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.handleQuery(ctx, header, q, writer)
+			}()
+		}
+	}
+
+	if err := task.Run(ctx, request); err != nil {
+		return newError("connection ends").Base(err)
+	}
+	return nil
+}
+
+// lockedWriter serializes responses of queries that are answered concurrently.
+type lockedWriter struct {
+	access sync.Mutex
+	writer dns_proto.MessageWriter
+}
+
+func (w *lockedWriter) WriteMessage(b *buf.Buffer) error {
+	w.access.Lock()
+	defer w.access.Unlock()
+	return w.writer.WriteMessage(b)
+}
+
+type tlsConnection interface {
+	Handshake() error
+	ConnectionState() tls.ConnectionState
+}
+
+func (s *Server) serveDoH(ctx context.Context, conn internet.Connection, timer signal.ActivityUpdater) error {
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	handler := &dohHandler{
+		responder: &s.responder,
+		path:      s.config.DohPath,
+		timer:     timer,
+	}
+
+	if tlsConn, ok := conn.(tlsConnection); ok {
+		if err := tlsConn.Handshake(); err != nil {
+			return newError("failed to complete TLS handshake").Base(err)
+		}
+		if tlsConn.ConnectionState().NegotiatedProtocol == http2.NextProtoTLS {
+			(&http2.Server{}).ServeConn(conn, &http2.ServeConnOpts{
+				Context: ctx,
+				Handler: handler,
+			})
+			return nil
+		}
+	}
+
+	// HTTP/1.1, or HTTP/2 with prior knowledge on a connection without TLS.
+	listener := newConnListener(conn)
+	server := &http.Server{
+		Handler: h2c.NewHandler(handler, &http2.Server{}),
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+		ConnState: func(_ net.Conn, state http.ConnState) {
+			if state == http.StateClosed || state == http.StateHijacked {
+				listener.Close()
+			}
+		},
+	}
+	if err := server.Serve(listener); err != nil && err != io.EOF {
+		return newError("failed to serve DoH").Base(err)
+	}
+	return nil
+}
+
+// dohHandler serves DNS over HTTPS (RFC 8484).
+type dohHandler struct {
+	*responder
+	path  string
+	timer signal.ActivityUpdater
+}
+
+func (h *dohHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.timer.Update()
+
+	if r.URL.Path != h.path {
+		http.NotFound(w, r)
+		return
+	}
+
+	var msg []byte
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		msg, err = base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+	case http.MethodPost:
+		if r.Header.Get("Content-Type") != "application/dns-message" {
+			http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+			return
+		}
+		msg, err = ioutil.ReadAll(io.LimitReader(r.Body, 65535))
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		http.Error(w, "invalid DNS message", http.StatusBadRequest)
+		return
+	}
+	isQuery, header, q := parseQuery(msg)
+	if !isQuery {
+		http.Error(w, "invalid DNS message", http.StatusBadRequest)
+		return
+	}
+
+	b, err := h.respond(r.Context(), header, q)
+	if err != nil {
+		newError("failed to answer query").Base(err).WriteToLog(session.ExportIDToError(r.Context()))
+		// Errors of DNS are reported in the DNS message, as the HTTP request itself succeeded.
+		b, err = packResponse(header, q, dnsmessage.RCodeServerFailure, nil)
+		if err != nil {
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+	defer b.Release()
+
+	w.Header().Set("Content-Type", "application/dns-message")
+	w.Write(b.Bytes()) // nolint: errcheck
+}
+
+// connListener is a net.Listener of a single connection. After the connection is accepted, Accept blocks until the
+// listener is closed.
+type connListener struct {
+	conn   net.Conn
+	accept chan net.Conn
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newConnListener(conn net.Conn) *connListener {
+	l := &connListener{
+		conn:   conn,
+		accept: make(chan net.Conn, 1),
+		done:   make(chan struct{}),
+	}
+	l.accept <- conn
+	return l
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.accept:
+		return conn, nil
+	case <-l.done:
+		return nil, io.EOF
+	}
+}
+
+func (l *connListener) Close() error {
+	l.once.Do(func() {
+		close(l.done)
+	})
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr {
+	return l.conn.LocalAddr()
+}
@@ -0,0 +1,201 @@
+package dns_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"v2ray.com/core"
+	"v2ray.com/core/app/dispatcher"
+	dnsapp "v2ray.com/core/app/dns"
+	"v2ray.com/core/app/policy"
+	"v2ray.com/core/app/proxyman"
+	"v2ray.com/core/app/router"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/serial"
+	"v2ray.com/core/proxy/blackhole"
+	dns_proxy "v2ray.com/core/proxy/dns"
+	"v2ray.com/core/proxy/freedom"
+	"v2ray.com/core/testing/servers/tcp"
+	"v2ray.com/core/testing/servers/udp"
+)
+
+func startDNSInbound(t *testing.T, serverConfig *dns_proxy.ServerConfig, serverPort net.Port) *core.Instance {
+	upstreamPort := udp.PickPort()
+	dnsServer := &dns.Server{
+		Addr:    "127.0.0.1:" + upstreamPort.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&dnsapp.Config{
+				NameServer: []*dnsapp.NameServer{
+					{
+						Address: &net.Endpoint{
+							Network: net.Network_UDP,
+							Address: net.NewIPOrDomain(net.LocalHostIP),
+							Port:    uint32(upstreamPort),
+						},
+					},
+				},
+				StaticHosts: []*dnsapp.Config_HostMapping{
+					{
+						Type:   dnsapp.DomainMatchingType_Full,
+						Domain: "local.v2ray.com",
+						Ip:     [][]byte{{10, 0, 0, 1}},
+					},
+				},
+			}),
+			// Upstream queries of clients are routed by the tag of the DNS inbound, or they are blocked.
+			serial.ToTypedMessage(&router.Config{
+				Rule: []*router.RoutingRule{
+					{
+						InboundTag: []string{"dns-server"},
+						TargetTag:  &router.RoutingRule_Tag{Tag: "direct"},
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Inbound: []*core.InboundHandlerConfig{
+			{
+				Tag:           "dns-server",
+				ProxySettings: serial.ToTypedMessage(serverConfig),
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: net.SinglePortRange(serverPort),
+					Listen:    net.NewIPOrDomain(net.LocalHostIP),
+				}),
+			},
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				Tag:           "blocked",
+				ProxySettings: serial.ToTypedMessage(&blackhole.Config{}),
+			},
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+	common.Must(v.Start())
+	t.Cleanup(func() {
+		v.Close()
+		dnsServer.Shutdown()
+	})
+	return v
+}
+
+func checkAnswerA(t *testing.T, in *dns.Msg, ip string) {
+	t.Helper()
+	if len(in.Answer) != 1 {
+		t.Fatal("len(answer): ", len(in.Answer))
+	}
+	rr, ok := in.Answer[0].(*dns.A)
+	if !ok {
+		t.Fatal("not A record")
+	}
+	if rr.A.String() != ip {
+		t.Error("unexpected IP: ", rr.A)
+	}
+}
+
+func TestDNSInbound(t *testing.T) {
+	serverPort := tcp.PickPort()
+	startDNSInbound(t, &dns_proxy.ServerConfig{
+		FullServer:             true,
+		UnsupportedQueryAction: dns_proxy.UnsupportedQueryAction_EMPTY,
+	}, serverPort)
+
+	for _, network := range []string{"udp", "tcp"} {
+		c := &dns.Client{Net: network}
+		exchange := func(name string, qType uint16) *dns.Msg {
+			m1 := new(dns.Msg)
+			m1.Id = dns.Id()
+			m1.RecursionDesired = true
+			m1.Question = []dns.Question{{Name: name, Qtype: qType, Qclass: dns.ClassINET}}
+
+			in, _, err := c.Exchange(m1, "127.0.0.1:"+serverPort.String())
+			common.Must(err)
+			return in
+		}
+
+		checkAnswerA(t, exchange("google.com.", dns.TypeA), "8.8.8.8")
+		checkAnswerA(t, exchange("local.v2ray.com.", dns.TypeA), "10.0.0.1")
+
+		if in := exchange("google.com.", dns.TypeMX); len(in.Answer) != 1 {
+			t.Error("len(answer): ", len(in.Answer))
+		}
+
+		in := exchange("google.com.", dns.TypeNAPTR)
+		if in.Rcode != dns.RcodeSuccess || len(in.Answer) != 0 {
+			t.Error("unexpected response to unsupported query: ", in)
+		}
+	}
+}
+
+func TestDNSInboundDoH(t *testing.T) {
+	serverPort := tcp.PickPort()
+	startDNSInbound(t, &dns_proxy.ServerConfig{
+		DohPath: "/dns-query",
+	}, serverPort)
+
+	m1 := new(dns.Msg)
+	m1.Question = []dns.Question{{Name: "google.com.", Qtype: dns.TypeA, Qclass: dns.ClassINET}}
+	query, err := m1.Pack()
+	common.Must(err)
+
+	url := "http://127.0.0.1:" + serverPort.String() + "/dns-query"
+	parse := func(resp *http.Response) *dns.Msg {
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatal("unexpected status: ", resp.Status)
+		}
+		if ct := resp.Header.Get("Content-Type"); ct != "application/dns-message" {
+			t.Fatal("unexpected content type: ", ct)
+		}
+		b, err := ioutil.ReadAll(resp.Body)
+		common.Must(err)
+		in := new(dns.Msg)
+		common.Must(in.Unpack(b))
+		return in
+	}
+
+	{
+		resp, err := http.Post(url, "application/dns-message", bytes.NewReader(query))
+		common.Must(err)
+		checkAnswerA(t, parse(resp), "8.8.8.8")
+	}
+
+	{
+		resp, err := http.Get(url + "?dns=" + base64.RawURLEncoding.EncodeToString(query))
+		common.Must(err)
+		checkAnswerA(t, parse(resp), "8.8.8.8")
+	}
+
+	{
+		resp, err := http.Get("http://127.0.0.1:" + serverPort.String() + "/other")
+		common.Must(err)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Error("unexpected status: ", resp.Status)
+		}
+	}
+}